@@ -1,31 +1,632 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"github.com/dmisiuk/acousticalc/pkg/version"
 )
 
 func main() {
-	// Check if an expression was provided as a command-line argument
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: acousticalc <expression>")
-		fmt.Println("Example: acousticalc \"2 + 3 * 4\"")
-		os.Exit(1)
+	os.Exit(runCLI(os.Args[1:], os.Stdin, os.Stdout))
+}
+
+// displayDigits is the number of significant digits shown for results,
+// enough to hide binary floating point noise such as 0.1 + 0.2
+const displayDigits = 15
+
+// cliOptions holds the flags accepted before the expression
+type cliOptions struct {
+	raw      bool // print results at full float64 precision
+	rpn      bool // print the expression in reverse Polish notation instead of evaluating it
+	rpnInput bool // read the expression in reverse Polish notation
+	json     bool // print each result as a JSON object
+
+	percentOfTotal bool // print each stdin value's share of their sum
+	version        bool // print build information instead of evaluating
+	repl           bool // read and evaluate lines interactively until exit
+
+	minResult *float64 // fail if a result is below this bound
+	maxResult *float64 // fail if a result is above this bound
+
+	precision *int // round displayed results to at most this many decimal places
+
+	file string // evaluate each line of this file, like an @file argument
+}
+
+// parseFlags consumes leading flags and returns the remaining arguments.
+// Only known flags are consumed so that negative numbers like "-5 + 3"
+// are still treated as expressions.
+func parseFlags(args []string) (cliOptions, []string, error) {
+	var opts cliOptions
+	for len(args) > 0 {
+		switch args[0] {
+		case "--raw":
+			opts.raw = true
+		case "--rpn":
+			opts.rpn = true
+		case "--rpn-input":
+			opts.rpnInput = true
+		case "--json":
+			opts.json = true
+		case "--percent-of-total":
+			opts.percentOfTotal = true
+		case "--version", "-v":
+			opts.version = true
+		case "--repl":
+			opts.repl = true
+		default:
+			name, value, hasValue := strings.Cut(args[0], "=")
+			if name != "--min-result" && name != "--max-result" && name != "--file" && name != "--precision" {
+				return opts, args, checkBoundRange(opts)
+			}
+
+			// The value is either joined with "=" or the next argument
+			if !hasValue {
+				if len(args) < 2 {
+					return opts, nil, fmt.Errorf("%s requires a value", name)
+				}
+				value = args[1]
+				args = args[1:]
+			}
+			if name == "--file" {
+				opts.file = value
+				break
+			}
+			if name == "--precision" {
+				places, err := strconv.Atoi(value)
+				if err != nil || places < 0 {
+					return opts, nil, fmt.Errorf("invalid value for %s: %q", name, value)
+				}
+				opts.precision = &places
+				break
+			}
+
+			bound, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return opts, nil, fmt.Errorf("invalid value for %s: %q", name, value)
+			}
+			if name == "--min-result" {
+				opts.minResult = &bound
+			} else {
+				opts.maxResult = &bound
+			}
+		}
+		args = args[1:]
+	}
+
+	return opts, args, checkBoundRange(opts)
+}
+
+// checkBoundRange rejects a --min-result above the --max-result
+func checkBoundRange(opts cliOptions) error {
+	if opts.minResult != nil && opts.maxResult != nil && *opts.minResult > *opts.maxResult {
+		return fmt.Errorf("--min-result %v is greater than --max-result %v", *opts.minResult, *opts.maxResult)
+	}
+	return nil
+}
+
+// runCLI evaluates the command-line arguments and returns the process exit code
+func runCLI(args []string, stdin io.Reader, stdout io.Writer) int {
+	opts, args, err := parseFlags(args)
+	if err != nil {
+		fmt.Fprintf(stdout, "Error: %v\n", err)
+		return 1
+	}
+
+	if opts.version {
+		fmt.Fprintln(stdout, version.Get())
+		return 0
+	}
+
+	if opts.repl {
+		if flag := replConflict(opts); flag != "" {
+			fmt.Fprintf(stdout, "Error: --repl cannot be combined with %s\n", flag)
+			return 1
+		}
+		return runREPL(stdin, opts, stdout)
+	}
+
+	if opts.percentOfTotal {
+		return printPercentOfTotal(stdin, opts, stdout)
+	}
+
+	if opts.file != "" {
+		if len(args) > 0 {
+			fmt.Fprintln(stdout, "Error: --file cannot be combined with an expression")
+			return 1
+		}
+		return evaluateFile(opts.file, opts, stdout)
+	}
+
+	// Without an expression, evaluate piped input line by line; at an
+	// interactive terminal there is nothing to read, so show the usage
+	if len(args) < 1 {
+		if isTerminal(stdin) {
+			printUsage(stdout)
+			return 1
+		}
+		return evaluateLines(stdin, opts, stdout)
+	}
+
+	// "eval -" (or "eval --") reads the whole of stdin as a single expression
+	if args[0] == "eval" {
+		args = args[1:]
+		if len(args) == 1 && (args[0] == "-" || args[0] == "--") {
+			input, err := io.ReadAll(stdin)
+			if err != nil {
+				fmt.Fprintf(stdout, "Error: failed to read stdin: %v\n", err)
+				return 1
+			}
+			return evaluateAndPrint(string(input), opts, stdout)
+		}
+	}
+
+	// "@path" evaluates every line of the named file, like curl's @file
+	if len(args) == 1 && strings.HasPrefix(args[0], "@") {
+		return evaluateFile(strings.TrimPrefix(args[0], "@"), opts, stdout)
 	}
 
 	// Join all arguments to handle expressions with spaces
-	expression := strings.Join(os.Args[1:], " ")
+	expression := strings.Join(args, " ")
+
+	// A quoted multi-line argument holds one expression per line, as in a file
+	if strings.Contains(expression, "\n") {
+		return evaluateLines(strings.NewReader(expression), opts, stdout)
+	}
+	return evaluateAndPrint(expression, opts, stdout)
+}
+
+// runREPL reads expressions line by line until exit, quit or the end of
+// input and prints each result. A calculator session carries variables and
+// ans from one line to the next, and errors are printed without ending the
+// session. A prompt is shown only when reading from a terminal.
+func runREPL(stdin io.Reader, opts cliOptions, stdout io.Writer) int {
+	calc := calculator.New()
+	prompt := isTerminal(stdin)
+	scanner := bufio.NewScanner(stdin)
+
+	for {
+		if prompt {
+			fmt.Fprint(stdout, "> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		switch line {
+		case "":
+			continue
+		case "exit", "quit":
+			return 0
+		}
+
+		result, err := calc.Eval(line)
+		if err == nil {
+			err = checkBounds(result, opts)
+		}
+		if err != nil {
+			fmt.Fprintf(stdout, "Error: %v\n", err)
+			continue
+		}
+		fmt.Fprintf(stdout, "Result: %s\n", formatResult(result, opts))
+	}
+
+	if prompt {
+		fmt.Fprintln(stdout)
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stdout, "Error: failed to read input: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// replConflict returns the first flag given with --repl that the session
+// does not support, or "" if there is none
+func replConflict(opts cliOptions) string {
+	switch {
+	case opts.rpn:
+		return "--rpn"
+	case opts.rpnInput:
+		return "--rpn-input"
+	case opts.json:
+		return "--json"
+	case opts.percentOfTotal:
+		return "--percent-of-total"
+	case opts.file != "":
+		return "--file"
+	default:
+		return ""
+	}
+}
+
+// isTerminal checks if r is an interactive terminal rather than a pipe,
+// file or in-memory reader
+func isTerminal(r io.Reader) bool {
+	file, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// evaluateFile evaluates each line of a file of expressions
+func evaluateFile(path string, opts cliOptions, stdout io.Writer) int {
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(stdout, "Error: failed to read expression file: %v\n", err)
+		return 1
+	}
+	defer file.Close()
+
+	return evaluateLines(file, opts, stdout)
+}
+
+// expressionLine is one expression of a file or of piped input, with the
+// value of its optional "=> expected" annotation
+type expressionLine struct {
+	expression string
+	expected   string
+	checked    bool
+}
+
+// evaluateLines evaluates one expression per line and prints "expression: result"
+// for each as soon as it is read, so input that never ends, such as
+// "tail -f log | acousticalc", still prints its results. Blank lines and lines
+// starting with # are skipped. A line may end with "=> expected" to check the
+// result; mismatches are reported after the result. The exit code is nonzero
+// if any line fails or any check mismatches.
+func evaluateLines(r io.Reader, opts cliOptions, stdout io.Writer) int {
+	exitCode := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		expression, expected, checked := strings.Cut(text, "=>")
+		line := expressionLine{
+			expression: strings.TrimSpace(expression),
+			expected:   strings.TrimSpace(expected),
+			checked:    checked,
+		}
+		if printLine(line, evaluateLine(line, opts), opts, stdout) != 0 {
+			exitCode = 1
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stdout, "Error: failed to read expressions: %v\n", err)
+		return 1
+	}
+
+	return exitCode
+}
+
+// evaluateLine evaluates one line as a single-entry calculator.EvaluateBatch,
+// which gives each line the same treatment as an entry of a batch. Postfix
+// input has no batch evaluator and is evaluated on its own, and --rpn output
+// needs no evaluation at all.
+func evaluateLine(line expressionLine, opts cliOptions) calculator.BatchResult {
+	if opts.rpn || opts.rpnInput {
+		return evaluateEntry(line.expression, opts)
+	}
+
+	// A failed line is reported on its own, so the summary error is not needed
+	results, _ := calculator.EvaluateBatch([]string{line.expression})
+	return results[0]
+}
+
+// printLine prints the result of one line as "expression: result", checked
+// against its expected value if it has one, and returns the exit code
+func printLine(line expressionLine, evaluated calculator.BatchResult, opts cliOptions, stdout io.Writer) int {
+	if opts.json {
+		result := evaluateJSON(evaluated, opts)
+		if line.checked {
+			result.check(line.expected, opts)
+		}
+		return printJSON(result, stdout)
+	}
+
+	result, err := evaluateForDisplay(evaluated, opts)
+	if err != nil {
+		fmt.Fprintf(stdout, "%s: Error: %v\n", line.expression, err)
+		return 1
+	}
+	if !line.checked {
+		fmt.Fprintf(stdout, "%s: %s\n", line.expression, result)
+		return 0
+	}
+
+	ok, err := matchesExpected(line.expression, line.expected, result, opts)
+	switch {
+	case err != nil:
+		fmt.Fprintf(stdout, "%s: %s (Error: %v)\n", line.expression, result, err)
+		return 1
+	case !ok:
+		fmt.Fprintf(stdout, "%s: %s (MISMATCH: expected %s)\n", line.expression, result, line.expected)
+		return 1
+	default:
+		fmt.Fprintf(stdout, "%s: %s (ok)\n", line.expression, result)
+		return 0
+	}
+}
+
+// checkTolerance is the relative and absolute tolerance for "=> expected" checks
+const checkTolerance = 1e-9
+
+// matchesExpected checks a line's result against its "=> expected" annotation.
+// Numeric results are compared within checkTolerance at full precision;
+// --rpn output is compared as text.
+func matchesExpected(expression, expected, display string, opts cliOptions) (bool, error) {
+	if opts.rpn {
+		return strings.Join(strings.Fields(expected), " ") == display, nil
+	}
+
+	want, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid expected value: %q", expected)
+	}
+	got, err := evaluateValue(expression, opts)
+	if err != nil {
+		return false, err
+	}
+	return calculator.AlmostEqual(got, want, checkTolerance, checkTolerance), nil
+}
+
+// printPercentOfTotal reads one value per line and prints each value with its
+// percentage of the sum, followed by the total. Blank lines and lines starting
+// with # are skipped. A zero total prints every share as 0%.
+func printPercentOfTotal(r io.Reader, opts cliOptions, stdout io.Writer) int {
+	var values []float64
+	var lines []string
+	total := 0.0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		value, err := calculator.Evaluate(line)
+		if err != nil {
+			fmt.Fprintf(stdout, "%s: Error: %v\n", line, err)
+			return 1
+		}
+		lines = append(lines, line)
+		values = append(values, value)
+		total += value
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stdout, "Error: failed to read values: %v\n", err)
+		return 1
+	}
+
+	for i, value := range values {
+		percent := 0.0
+		if total != 0 {
+			percent = value / total * 100
+		}
+		fmt.Fprintf(stdout, "%s: %s%%\n", lines[i], formatResult(percent, opts))
+	}
+	fmt.Fprintf(stdout, "Total: %s\n", formatResult(total, opts))
+	if total == 0 && len(values) > 0 {
+		fmt.Fprintln(stdout, "Note: total is zero, percentages shown as 0%")
+	}
+	return 0
+}
+
+// evaluateAndPrint evaluates a single expression and prints its result
+func evaluateAndPrint(expression string, opts cliOptions, stdout io.Writer) int {
+	if opts.json {
+		return printJSON(evaluateJSON(evaluateEntry(expression, opts), opts), stdout)
+	}
+
+	result, err := evaluateForDisplay(evaluateEntry(expression, opts), opts)
+	if err != nil {
+		fmt.Fprintf(stdout, "Error: %v\n", err)
+		return 1
+	}
+
+	if opts.rpn {
+		fmt.Fprintf(stdout, "RPN: %s\n", result)
+		return 0
+	}
+	fmt.Fprintf(stdout, "Result: %s\n", result)
+	return 0
+}
+
+// jsonResult is the outcome of evaluating one expression as printed by --json
+type jsonResult struct {
+	Expression string   `json:"expression"`
+	Result     *float64 `json:"result,omitempty"` // Unset with --rpn and on error
+	RPN        string   `json:"rpn,omitempty"`    // Postfix form, with --rpn
+	Error      string   `json:"error,omitempty"`
+	Expected   string   `json:"expected,omitempty"` // From an "=> expected" line
+	OK         *bool    `json:"ok,omitempty"`       // Whether the expected value matched
+}
+
+// evaluateJSON turns an evaluated expression into the structure printed by
+// --json. Results are rounded for display like text output unless --raw was
+// given.
+func evaluateJSON(evaluated calculator.BatchResult, opts cliOptions) jsonResult {
+	result := jsonResult{Expression: strings.TrimSpace(evaluated.Input)}
+	if opts.rpn {
+		rpn, err := calculator.ToRPN(evaluated.Input)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.RPN = strings.Join(rpn, " ")
+		return result
+	}
+
+	value, err := evaluated.Result, evaluated.Err
+	if err == nil {
+		err = checkBounds(value, opts)
+	}
+	if err == nil && (math.IsNaN(value) || math.IsInf(value, 0)) {
+		// JSON has no representation for these
+		err = fmt.Errorf("result %v is not a finite number", value)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
 
-	// Evaluate the expression
-	result, err := calculator.Evaluate(expression)
+	value = displayValue(value, opts)
+	result.Result = &value
+	return result
+}
+
+// check compares a successful result against the value of an
+// "=> expected" line, recording a failed comparison as an error
+func (r *jsonResult) check(expected string, opts cliOptions) {
+	if r.Error != "" {
+		return
+	}
+	r.Expected = expected
+
+	display := r.RPN
+	if r.Result != nil {
+		display = formatResult(*r.Result, opts)
+	}
+	ok, err := matchesExpected(r.Expression, expected, display, opts)
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+	r.OK = &ok
+}
+
+// printJSON prints a result as a single line of JSON and returns the exit
+// code: nonzero for an error or a mismatched expected value
+func printJSON(result jsonResult, stdout io.Writer) int {
+	data, err := json.Marshal(result)
+	if err != nil {
+		fmt.Fprintf(stdout, "Error: failed to encode result: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(stdout, string(data))
+
+	if result.Error != "" || (result.OK != nil && !*result.OK) {
+		return 1
+	}
+	return 0
+}
+
+// evaluateForDisplay returns the text to print for an evaluated expression:
+// the formatted result, or the postfix form when --rpn was given
+func evaluateForDisplay(evaluated calculator.BatchResult, opts cliOptions) (string, error) {
+	if opts.rpn {
+		rpn, err := calculator.ToRPN(evaluated.Input)
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(rpn, " "), nil
+	}
+
+	if evaluated.Err != nil {
+		return "", evaluated.Err
+	}
+	if err := checkBounds(evaluated.Result, opts); err != nil {
+		return "", err
+	}
+	return formatResult(evaluated.Result, opts), nil
+}
+
+// evaluateEntry evaluates a single expression into the same form as an
+// entry of calculator.EvaluateBatch. With --rpn nothing is evaluated.
+func evaluateEntry(expression string, opts cliOptions) calculator.BatchResult {
+	evaluated := calculator.BatchResult{Input: expression}
+	if !opts.rpn {
+		evaluated.Result, evaluated.Err = evaluateValue(expression, opts)
+	}
+	return evaluated
+}
+
+// checkBounds verifies that a result lies within --min-result and --max-result
+func checkBounds(result float64, opts cliOptions) error {
+	if opts.minResult != nil && !(result >= *opts.minResult) {
+		return fmt.Errorf("result %s is below --min-result %v", formatResult(result, opts), *opts.minResult)
+	}
+	if opts.maxResult != nil && !(result <= *opts.maxResult) {
+		return fmt.Errorf("result %s is above --max-result %v", formatResult(result, opts), *opts.maxResult)
+	}
+	return nil
+}
+
+// evaluateValue evaluates an expression in infix or, with --rpn-input,
+// postfix notation
+func evaluateValue(expression string, opts cliOptions) (float64, error) {
+	if opts.rpnInput {
+		return calculator.EvaluateRPN(expression)
+	}
+	return calculator.Evaluate(expression)
+}
+
+// formatResult formats a result for display. With --precision the value is
+// rounded to that many decimal places, otherwise unless raw output was
+// requested to displayDigits significant digits; the calculation itself
+// always keeps full precision.
+func formatResult(result float64, opts cliOptions) string {
+	if opts.precision != nil {
+		return calculator.FormatResult(result, calculator.FormatOptions{DecimalPlaces: opts.precision})
+	}
+	return fmt.Sprintf("%v", displayValue(result, opts))
+}
+
+// displayValue rounds a result to --precision decimal places, or to
+// displayDigits significant digits unless raw output was requested
+func displayValue(result float64, opts cliOptions) float64 {
+	if opts.precision != nil {
+		rounded, err := strconv.ParseFloat(formatResult(result, opts), 64)
+		if err != nil {
+			return result
+		}
+		return rounded
+	}
+	if opts.raw {
+		return result
+	}
+
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(result, 'g', displayDigits, 64), 64)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		return result
 	}
+	return rounded
+}
 
-	// Print the result
-	fmt.Printf("Result: %v\n", result)
+// printUsage prints the command-line usage message
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: acousticalc <expression>")
+	fmt.Fprintln(w, "       acousticalc eval -    (read one expression from stdin)")
+	fmt.Fprintln(w, "       acousticalc @file     (evaluate each line of file; \"expr => expected\" lines are checked)")
+	fmt.Fprintln(w, "       ... | acousticalc     (evaluate each line piped to stdin, as with @file)")
+	fmt.Fprintln(w, "       acousticalc --repl    (evaluate lines interactively with variables and ans; exit or quit to leave)")
+	fmt.Fprintln(w, "Options:")
+	fmt.Fprintln(w, "  --raw        print results at full precision")
+	fmt.Fprintln(w, "  --rpn        print the expression in reverse Polish notation")
+	fmt.Fprintln(w, "  --rpn-input  read the expression in reverse Polish notation")
+	fmt.Fprintln(w, "  --json       print each result as a JSON object")
+	fmt.Fprintln(w, "  --percent-of-total  read values from stdin and print each one's share of the sum")
+	fmt.Fprintln(w, "  --min-result N      fail if the result is below N")
+	fmt.Fprintln(w, "  --max-result N      fail if the result is above N")
+	fmt.Fprintln(w, "  --file PATH         evaluate each line of PATH, like @PATH")
+	fmt.Fprintln(w, "  --precision N       round results to at most N decimal places")
+	fmt.Fprintln(w, "  --version, -v       print the version, commit and build date")
+	fmt.Fprintln(w, "Example: acousticalc \"2 + 3 * 4\"")
 }
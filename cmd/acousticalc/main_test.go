@@ -0,0 +1,694 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmisiuk/acousticalc/pkg/version"
+)
+
+// TestRunCLIEvalStdin tests that "eval -" reads the whole of stdin as one expression
+func TestRunCLIEvalStdin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+		exitCode int
+	}{
+		{
+			name:     "Single line",
+			args:     []string{"eval", "-"},
+			stdin:    "2 + 3 * 4\n",
+			expected: "Result: 14",
+		},
+		{
+			name:     "Expression spread over lines",
+			args:     []string{"eval", "-"},
+			stdin:    "(2 + 3)\n* 4\n- 5\n",
+			expected: "Result: 15",
+		},
+		{
+			name:     "Double dash alias",
+			args:     []string{"eval", "--"},
+			stdin:    "10 / 4",
+			expected: "Result: 2.5",
+		},
+		{
+			name:     "Invalid expression on stdin",
+			args:     []string{"eval", "-"},
+			stdin:    "2 +\n",
+			expected: "Error:",
+			exitCode: 1,
+		},
+		{
+			name:     "Empty stdin",
+			args:     []string{"eval", "-"},
+			stdin:    "",
+			expected: "Error: empty expression",
+			exitCode: 1,
+		},
+		{
+			name:     "Eval with inline expression",
+			args:     []string{"eval", "6", "*", "7"},
+			expected: "Result: 42",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			code := runCLI(tc.args, strings.NewReader(tc.stdin), &stdout)
+
+			if code != tc.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tc.exitCode, code)
+			}
+
+			actual := strings.TrimSpace(stdout.String())
+			if !strings.HasPrefix(actual, tc.expected) {
+				t.Errorf("Expected output to start with %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestRunCLIDisplayRounding tests that floating point noise is hidden unless --raw is given
+func TestRunCLIDisplayRounding(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"Default display is rounded", []string{"0.1 + 0.2"}, "Result: 0.3"},
+		{"Raw display keeps full precision", []string{"--raw", "0.1 + 0.2"}, "Result: 0.30000000000000004"},
+		{"Exact results are unchanged", []string{"2 * (3 + 4) - 5 / 2"}, "Result: 11.5"},
+		{"Repeating decimals keep significant digits", []string{"1 / 3"}, "Result: 0.333333333333333"},
+		{"Raw with eval subcommand", []string{"--raw", "eval", "1.1 * 3"}, "Result: 3.3000000000000003"},
+		{"Rounded with eval subcommand", []string{"eval", "1.1 * 3"}, "Result: 3.3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(""), &stdout); code != 0 {
+				t.Fatalf("Expected exit code 0, got %d: %s", code, stdout.String())
+			}
+
+			actual := strings.TrimSpace(stdout.String())
+			if actual != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestRunCLIFileArgument tests evaluating a file named by an @-prefixed argument
+func TestRunCLIFileArgument(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "calc.txt")
+	content := "2 + 3\n\n# subtotal\n(2 + 3) * 4\n10 / 4\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write expression file: %v", err)
+	}
+
+	t.Run("Evaluates each line", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"@" + path}, strings.NewReader(""), &stdout); code != 0 {
+			t.Fatalf("Expected exit code 0, got %d: %s", code, stdout.String())
+		}
+
+		expected := "2 + 3: 5\n(2 + 3) * 4: 20\n10 / 4: 2.5\n"
+		if stdout.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, stdout.String())
+		}
+	})
+
+	t.Run("Invalid line fails the run", func(t *testing.T) {
+		badPath := filepath.Join(dir, "bad.txt")
+		if err := os.WriteFile(badPath, []byte("1 + 1\n2 +\n"), 0644); err != nil {
+			t.Fatalf("Failed to write expression file: %v", err)
+		}
+
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"@" + badPath}, strings.NewReader(""), &stdout); code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+		if !strings.Contains(stdout.String(), "1 + 1: 2\n") || !strings.Contains(stdout.String(), "2 +: Error:") {
+			t.Errorf("Unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		var stdout bytes.Buffer
+		code := runCLI([]string{"@" + filepath.Join(dir, "missing.txt")}, strings.NewReader(""), &stdout)
+		if code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+		if !strings.HasPrefix(stdout.String(), "Error: failed to read expression file:") {
+			t.Errorf("Expected a clear missing-file error, got %q", stdout.String())
+		}
+	})
+
+	t.Run("Plain arguments are still expressions", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"2", "*", "21"}, strings.NewReader(""), &stdout); code != 0 {
+			t.Fatalf("Expected exit code 0, got %d", code)
+		}
+		if strings.TrimSpace(stdout.String()) != "Result: 42" {
+			t.Errorf("Expected %q, got %q", "Result: 42", stdout.String())
+		}
+	})
+}
+
+// TestRunCLIRPN tests printing expressions in reverse Polish notation
+func TestRunCLIRPN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+		exitCode int
+	}{
+		{"Precedence", []string{"--rpn", "2 + 3 * 4"}, "RPN: 2 3 4 * +", 0},
+		{"Split arguments", []string{"--rpn", "(2", "+", "3)", "*", "4"}, "RPN: 2 3 + 4 *", 0},
+		{"Combined with raw", []string{"--raw", "--rpn", "1 - 2 - 3"}, "RPN: 1 2 - 3 -", 0},
+		{"Invalid expression", []string{"--rpn", "2 +"}, "Error: invalid expression", 1},
+		{"Postfix input", []string{"--rpn-input", "2 3 4 * +"}, "Result: 14", 0},
+		{"Postfix input split arguments", []string{"--rpn-input", "2", "3", "4", "*", "+"}, "Result: 14", 0},
+		{"Postfix input underflow", []string{"--rpn-input", "2 +"}, "Error: stack underflow at operator +", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(""), &stdout); code != tc.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tc.exitCode, code)
+			}
+			if actual := strings.TrimSpace(stdout.String()); actual != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestRunCLIPercentOfTotal tests printing each stdin value's share of the total
+func TestRunCLIPercentOfTotal(t *testing.T) {
+	t.Run("Sample column", func(t *testing.T) {
+		var stdout bytes.Buffer
+		stdin := "30\n\n# costs\n45\n15\n10\n"
+		if code := runCLI([]string{"--percent-of-total"}, strings.NewReader(stdin), &stdout); code != 0 {
+			t.Fatalf("Expected exit code 0, got %d: %s", code, stdout.String())
+		}
+
+		expected := "30: 30%\n45: 45%\n15: 15%\n10: 10%\nTotal: 100\n"
+		if stdout.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, stdout.String())
+		}
+	})
+
+	t.Run("Percentages sum to 100", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"--percent-of-total"}, strings.NewReader("1\n1\n1\n2.5\n"), &stdout); code != 0 {
+			t.Fatalf("Expected exit code 0, got %d", code)
+		}
+
+		sum := 0.0
+		for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+			if !strings.HasSuffix(line, "%") {
+				continue
+			}
+			_, percent, _ := strings.Cut(strings.TrimSuffix(line, "%"), ": ")
+			value, err := strconv.ParseFloat(percent, 64)
+			if err != nil {
+				t.Fatalf("Failed to parse percentage in %q: %v", line, err)
+			}
+			sum += value
+		}
+		if sum < 99.999999 || sum > 100.000001 {
+			t.Errorf("Expected percentages to sum to 100, got %v", sum)
+		}
+	})
+
+	t.Run("Zero total", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"--percent-of-total"}, strings.NewReader("5\n-5\n"), &stdout); code != 0 {
+			t.Fatalf("Expected exit code 0, got %d", code)
+		}
+
+		expected := "5: 0%\n-5: 0%\nTotal: 0\nNote: total is zero, percentages shown as 0%\n"
+		if stdout.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, stdout.String())
+		}
+	})
+
+	t.Run("Invalid value", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"--percent-of-total"}, strings.NewReader("5\nabc\n"), &stdout); code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+		if !strings.Contains(stdout.String(), "abc: Error:") {
+			t.Errorf("Expected error for invalid value, got %q", stdout.String())
+		}
+	})
+}
+
+// TestRunCLIExpectedResults tests checking "=> expected" annotations in file mode
+func TestRunCLIExpectedResults(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write expression file: %v", err)
+		}
+		return path
+	}
+
+	t.Run("Mixed checked and unchecked lines", func(t *testing.T) {
+		path := writeFixture("mixed.txt", "2 + 3 => 5\n10 / 4\n0.1 + 0.2 => 0.3\n2 * 3 => 7\n# done\n")
+
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"@" + path}, strings.NewReader(""), &stdout); code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+
+		expected := "2 + 3: 5 (ok)\n10 / 4: 2.5\n0.1 + 0.2: 0.3 (ok)\n2 * 3: 6 (MISMATCH: expected 7)\n"
+		if stdout.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, stdout.String())
+		}
+	})
+
+	t.Run("All checks pass", func(t *testing.T) {
+		path := writeFixture("pass.txt", "1 / 3 => 0.333333333333\n2 ^ 10 => 1024\n")
+
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"@" + path}, strings.NewReader(""), &stdout); code != 0 {
+			t.Errorf("Expected exit code 0, got %d: %s", code, stdout.String())
+		}
+	})
+
+	t.Run("Invalid expected value", func(t *testing.T) {
+		path := writeFixture("invalid.txt", "2 + 3 => five\n")
+
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"@" + path}, strings.NewReader(""), &stdout); code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+		if !strings.Contains(stdout.String(), `2 + 3: 5 (Error: invalid expected value: "five")`) {
+			t.Errorf("Unexpected output: %q", stdout.String())
+		}
+	})
+
+	t.Run("RPN output is compared as text", func(t *testing.T) {
+		path := writeFixture("rpn.txt", "2 + 3 * 4 => 2 3 4 * +\n")
+
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"--rpn", "@" + path}, strings.NewReader(""), &stdout); code != 0 {
+			t.Errorf("Expected exit code 0, got %d: %s", code, stdout.String())
+		}
+	})
+}
+
+// TestRunCLIResultBounds tests failing when the result is outside --min-result and --max-result
+func TestRunCLIResultBounds(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+		exitCode int
+	}{
+		{"Inside bounds", []string{"--min-result", "0", "--max-result", "100", "6 * 7"}, "Result: 42", 0},
+		{"On the bounds", []string{"--min-result", "42", "--max-result", "42", "6 * 7"}, "Result: 42", 0},
+		{"Below minimum", []string{"--min-result", "0", "2 - 5"}, "Error: result -3 is below --min-result 0", 1},
+		{"Above maximum", []string{"--max-result", "10", "3 * 5"}, "Error: result 15 is above --max-result 10", 1},
+		{"Joined value", []string{"--max-result=10", "3 * 5"}, "Error: result 15 is above --max-result 10", 1},
+		{"Negative bound", []string{"--min-result", "-5", "-4"}, "Result: -4", 0},
+		{"Only maximum", []string{"--max-result", "10", "-1000"}, "Result: -1000", 0},
+		{"Missing value", []string{"--max-result"}, "Error: --max-result requires a value", 1},
+		{"Invalid value", []string{"--min-result", "low", "1"}, `Error: invalid value for --min-result: "low"`, 1},
+		{"Empty range", []string{"--min-result", "5", "--max-result", "1", "3"}, "Error: --min-result 5 is greater than --max-result 1", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(""), &stdout); code != tc.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tc.exitCode, code)
+			}
+			if actual := strings.TrimSpace(stdout.String()); actual != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestRunCLIMultiLineArgument tests that a multi-line argument is evaluated line by line
+func TestRunCLIMultiLineArgument(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+		exitCode int
+	}{
+		{"One result per line", []string{"2 + 3\n10 / 4\n6 * 7"}, "2 + 3: 5\n10 / 4: 2.5\n6 * 7: 42\n", 0},
+		{"Blank lines and CRLF", []string{"1 + 1\r\n\r\n2 * 2\r\n"}, "1 + 1: 2\n2 * 2: 4\n", 0},
+		{"Failing line", []string{"1 + 1\n2 +\n3"}, "1 + 1: 2\n2 +: Error: invalid expression\n3: 3\n", 1},
+		{"Split across arguments", []string{"1 +", "1\n2 *", "3"}, "1 + 1: 2\n2 * 3: 6\n", 0},
+		{"RPN output", []string{"--rpn", "1 + 2\n3 * 4"}, "1 + 2: 1 2 +\n3 * 4: 3 4 *\n", 0},
+		{"Single line unchanged", []string{"2 + 3"}, "Result: 5\n", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(""), &stdout); code != tc.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tc.exitCode, code)
+			}
+			if stdout.String() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, stdout.String())
+			}
+		})
+	}
+}
+
+// TestRunCLIVersion tests that --version prints build information without evaluating
+func TestRunCLIVersion(t *testing.T) {
+	defer func(v, c, d string) { version.Version, version.Commit, version.Date = v, c, d }(version.Version, version.Commit, version.Date)
+	version.Version, version.Commit, version.Date = "1.2.0", "abc1234", "2025-01-02T03:04:05Z"
+
+	expected := "acousticalc 1.2.0 (commit abc1234, built 2025-01-02T03:04:05Z)\n"
+	for _, args := range [][]string{{"--version"}, {"-v"}, {"--raw", "--version", "2 +"}} {
+		var stdout bytes.Buffer
+		if code := runCLI(args, strings.NewReader(""), &stdout); code != 0 {
+			t.Errorf("%v: expected exit code 0, got %d", args, code)
+		}
+		if stdout.String() != expected {
+			t.Errorf("%v: expected %q, got %q", args, expected, stdout.String())
+		}
+	}
+
+	// The toolchain's commit time is not passed off as the build date
+	info := version.Info{Version: "1.2.0", Commit: "abc1234", Date: "unknown", CommitDate: "2025-01-01T00:00:00Z"}
+	if got, want := info.String(), "acousticalc 1.2.0 (commit abc1234, committed 2025-01-01T00:00:00Z)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestRunCLIPipedStdin tests evaluating piped stdin line by line when no expression is given
+func TestRunCLIPipedStdin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+		exitCode int
+	}{
+		{"Single line", nil, "2+3\n", "2+3: 5\n", 0},
+		{"One result per line", nil, "2 + 3\n\n# total\n10 / 4\n6 * 7", "2 + 3: 5\n10 / 4: 2.5\n6 * 7: 42\n", 0},
+		{"Failing line", nil, "1 + 1\n2 +\n3\n", "1 + 1: 2\n2 +: Error: invalid expression\n3: 3\n", 1},
+		{"Flags apply to each line", []string{"--rpn"}, "1 + 2\n", "1 + 2: 1 2 +\n", 0},
+		{"Empty input", nil, "", "", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(tc.stdin), &stdout); code != tc.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tc.exitCode, code)
+			}
+			if stdout.String() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, stdout.String())
+			}
+		})
+	}
+
+	// Each result is printed as soon as its line is read, before the input ends
+	t.Run("Streams results", func(t *testing.T) {
+		stdinReader, stdinWriter := io.Pipe()
+		stdoutReader, stdoutWriter := io.Pipe()
+		done := make(chan int)
+		go func() {
+			done <- runCLI(nil, stdinReader, stdoutWriter)
+		}()
+
+		lines := make(chan string)
+		go func() {
+			output := bufio.NewReader(stdoutReader)
+			for {
+				line, err := output.ReadString('\n')
+				if err != nil {
+					return
+				}
+				lines <- line
+			}
+		}()
+
+		for _, tc := range []struct{ input, output string }{
+			{"2 + 3\n", "2 + 3: 5\n"},
+			{"6 * 7\n", "6 * 7: 42\n"},
+		} {
+			if _, err := io.WriteString(stdinWriter, tc.input); err != nil {
+				t.Fatalf("Failed to write %q: %v", tc.input, err)
+			}
+			select {
+			case line := <-lines:
+				if line != tc.output {
+					t.Errorf("Expected %q, got %q", tc.output, line)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("No result for %q before the input ended", tc.input)
+			}
+		}
+
+		stdinWriter.Close()
+		if code := <-done; code != 0 {
+			t.Errorf("Expected exit code 0, got %d", code)
+		}
+		stdoutWriter.Close()
+	})
+
+	// A terminal is a character device, as is the null device used here so
+	// the test does not need one
+	t.Run("Terminal shows usage", func(t *testing.T) {
+		tty, err := os.Open(os.DevNull)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", os.DevNull, err)
+		}
+		defer tty.Close()
+
+		var stdout bytes.Buffer
+		if code := runCLI(nil, tty, &stdout); code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+		if !strings.HasPrefix(stdout.String(), "Usage: acousticalc") {
+			t.Errorf("Expected usage, got %q", stdout.String())
+		}
+	})
+}
+
+// TestRunCLIJSON tests machine-readable output with --json
+func TestRunCLIJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+		exitCode int
+	}{
+		{"Result", []string{"--json", "2+3"}, "", `{"expression":"2+3","result":5}`, 0},
+		{"Split arguments", []string{"--json", "6", "*", "7"}, "", `{"expression":"6 * 7","result":42}`, 0},
+		{"Rounded for display", []string{"--json", "0.1 + 0.2"}, "", `{"expression":"0.1 + 0.2","result":0.3}`, 0},
+		{"Raw", []string{"--json", "--raw", "0.1 + 0.2"}, "", `{"expression":"0.1 + 0.2","result":0.30000000000000004}`, 0},
+		{"Zero result", []string{"--json", "2 - 2"}, "", `{"expression":"2 - 2","result":0}`, 0},
+		{"Error", []string{"--json", "2 +"}, "", `{"expression":"2 +","error":"invalid expression"}`, 1},
+		{"Not finite", []string{"--json", "2 ^ 5000"}, "", `{"expression":"2 ^ 5000","error":"result +Inf is not a finite number"}`, 1},
+		{"Out of bounds", []string{"--json", "--max-result", "10", "3 * 5"}, "", `{"expression":"3 * 5","error":"result 15 is above --max-result 10"}`, 1},
+		{"RPN", []string{"--json", "--rpn", "2 + 3"}, "", `{"expression":"2 + 3","rpn":"2 3 +"}`, 0},
+		{"Stdin expression", []string{"--json", "eval", "-"}, "2 * 21\n", `{"expression":"2 * 21","result":42}`, 0},
+		{
+			"One object per piped line", []string{"--json"}, "1 + 1\n\n2 +\n",
+			`{"expression":"1 + 1","result":2}` + "\n" + `{"expression":"2 +","error":"invalid expression"}`, 1,
+		},
+		{
+			"Expected values", []string{"--json"}, "1 + 1 => 2\n2 * 3 => 7\n",
+			`{"expression":"1 + 1","result":2,"expected":"2","ok":true}` + "\n" +
+				`{"expression":"2 * 3","result":6,"expected":"7","ok":false}`, 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(tc.stdin), &stdout); code != tc.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tc.exitCode, code)
+			}
+			if actual := strings.TrimSpace(stdout.String()); actual != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestRunCLIREPL tests the line-based interactive session
+func TestRunCLIREPL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+	}{
+		{
+			"Session with a bad line and exit",
+			[]string{"--repl"},
+			"2 + 3\nans * 2\n2 +\nx = ans + 1\n\nx / 2\nexit\n100\n",
+			"Result: 5\nResult: 10\nError: invalid expression\nResult: 11\nResult: 5.5\n",
+		},
+		{"Quit", []string{"--repl"}, "1\nquit\n2\n", "Result: 1\n"},
+		{"End of input", []string{"--repl"}, "6 * 7", "Result: 42\n"},
+		{"Errors keep ans", []string{"--repl"}, "4\n1 / 0\nans\n", "Result: 4\nError: division by zero\nResult: 4\n"},
+		{"Display flags", []string{"--raw", "--repl"}, "0.1 + 0.2\n", "Result: 0.30000000000000004\n"},
+		{"Bounds", []string{"--repl", "--max-result", "10"}, "20\n5\n", "Error: result 20 is above --max-result 10\nResult: 5\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(tc.stdin), &stdout); code != 0 {
+				t.Errorf("Expected exit code 0, got %d", code)
+			}
+			if stdout.String() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, stdout.String())
+			}
+		})
+	}
+}
+
+// TestRunCLIREPLConflicts tests that flags the session cannot honor are usage errors
+func TestRunCLIREPLConflicts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"RPN output", []string{"--repl", "--rpn"}, "Error: --repl cannot be combined with --rpn\n"},
+		{"RPN input", []string{"--rpn-input", "--repl"}, "Error: --repl cannot be combined with --rpn-input\n"},
+		{"JSON", []string{"--repl", "--json"}, "Error: --repl cannot be combined with --json\n"},
+		{"Percent of total", []string{"--percent-of-total", "--repl"}, "Error: --repl cannot be combined with --percent-of-total\n"},
+		{"File", []string{"--repl", "--file", "expressions.txt"}, "Error: --repl cannot be combined with --file\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader("2 + 3\n"), &stdout); code != 1 {
+				t.Errorf("Expected exit code 1, got %d", code)
+			}
+			if stdout.String() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, stdout.String())
+			}
+		})
+	}
+}
+
+// TestRunCLIFileFlag tests evaluating a file named by --file
+func TestRunCLIFileFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "expressions.txt")
+	content := "2 + 3\n\n# comment\n   \n10 / 4\n2 +\n1 / 0\n6 * 7\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write expression file: %v", err)
+	}
+	expected := "2 + 3: 5\n10 / 4: 2.5\n2 +: Error: invalid expression\n1 / 0: Error: division by zero\n6 * 7: 42\n"
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+		exitCode int
+	}{
+		{"Separate value", []string{"--file", path}, expected, 1},
+		{"Joined value", []string{"--file=" + path}, expected, 1},
+		{"With other flags", []string{"--raw", "--file", path, "--max-result", "100"}, expected, 1},
+		{"Missing value", []string{"--file"}, "Error: --file requires a value\n", 1},
+		{"With an expression", []string{"--file", path, "1 + 1"}, "Error: --file cannot be combined with an expression\n", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(""), &stdout); code != tc.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tc.exitCode, code)
+			}
+			if stdout.String() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, stdout.String())
+			}
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"--file", path + ".missing"}, strings.NewReader(""), &stdout); code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+		if !strings.HasPrefix(stdout.String(), "Error: failed to read expression file:") {
+			t.Errorf("Expected a clear missing-file error, got %q", stdout.String())
+		}
+	})
+
+	t.Run("All lines pass", func(t *testing.T) {
+		okPath := filepath.Join(t.TempDir(), "ok.txt")
+		if err := os.WriteFile(okPath, []byte("# header\n1 + 1\n\n2 * 2\n"), 0644); err != nil {
+			t.Fatalf("Failed to write expression file: %v", err)
+		}
+
+		var stdout bytes.Buffer
+		if code := runCLI([]string{"--file", okPath}, strings.NewReader(""), &stdout); code != 0 {
+			t.Errorf("Expected exit code 0, got %d: %s", code, stdout.String())
+		}
+	})
+}
+
+// TestRunCLIPrecision tests rounding displayed results to a number of decimal places
+func TestRunCLIPrecision(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+		exitCode int
+	}{
+		{"Two places", []string{"--precision", "2", "10/3"}, "Result: 3.33", 0},
+		{"Joined value", []string{"--precision=4", "2/3"}, "Result: 0.6667", 0},
+		{"Zero places rounds to an integer", []string{"--precision", "0", "10/3"}, "Result: 3", 0},
+		{"Zero places rounds up", []string{"--precision", "0", "2.5 + 1"}, "Result: 4", 0},
+		{"Half rounds up like round()", []string{"--precision", "0", "2.5"}, "Result: 3", 0},
+		{"Exact binary half rounds up", []string{"--precision", "2", "0.125"}, "Result: 0.13", 0},
+		{"Written half rounds up", []string{"--precision", "2", "1.005"}, "Result: 1.01", 0},
+		{"Matches round()", []string{"--precision", "2", "round(1.005, 2)"}, "Result: 1.01", 0},
+		{"Trailing zeros dropped", []string{"--precision", "3", "10/4"}, "Result: 2.5", 0},
+		{"Integer result", []string{"--precision", "2", "6 * 7"}, "Result: 42", 0},
+		{"Negative result", []string{"--precision", "1", "-10/3"}, "Result: -3.3", 0},
+		{"Large result is not in exponent form", []string{"--precision", "2", "10 ^ 21"}, "Result: 1000000000000000000000", 0},
+		{"Overrides raw", []string{"--raw", "--precision", "2", "0.1 + 0.2"}, "Result: 0.3", 0},
+		{"Default unchanged", []string{"10/3"}, "Result: 3.33333333333333", 0},
+		{"JSON", []string{"--json", "--precision", "2", "10/3"}, `{"expression":"10/3","result":3.33}`, 0},
+		{"Negative value", []string{"--precision", "-1", "10/3"}, `Error: invalid value for --precision: "-1"`, 1},
+		{"Fractional value", []string{"--precision", "1.5", "10/3"}, `Error: invalid value for --precision: "1.5"`, 1},
+		{"Missing value", []string{"--precision"}, "Error: --precision requires a value", 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			if code := runCLI(tc.args, strings.NewReader(""), &stdout); code != tc.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tc.exitCode, code)
+			}
+			if actual := strings.TrimSpace(stdout.String()); actual != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// AngleMode selects the unit trigonometric functions use for angles
+type AngleMode int
+
+const (
+	// Radians is the default angle mode
+	Radians AngleMode = iota
+	// Degrees makes sin, cos and tan take degrees and their inverses return degrees
+	Degrees
+)
+
+// String returns the name of the angle mode
+func (m AngleMode) String() string {
+	if m == Degrees {
+		return "degrees"
+	}
+	return "radians"
+}
+
+// SetAngleMode sets the angle unit used by trigonometric functions
+func (c *Calculator) SetAngleMode(mode AngleMode) {
+	c.angleMode = mode
+}
+
+// AngleMode returns the angle unit used by trigonometric functions
+func (c *Calculator) AngleMode() AngleMode {
+	return c.angleMode
+}
+
+// callAngleFunction calls a built-in function, converting the argument of
+// sin, cos and tan and the result of their inverses when in degrees mode
+func callAngleFunction(name string, args []float64, mode AngleMode) (float64, error) {
+	if mode != Degrees || len(args) != 1 {
+		return callFunction(name, args)
+	}
+
+	switch name {
+	case "sin":
+		return degreeSine(args[0]), nil
+	case "cos":
+		return degreeSine(reduceDegrees(args[0]) + 90), nil
+	case "tan":
+		cos := degreeSine(reduceDegrees(args[0]) + 90)
+		if cos == 0 {
+			return 0, fmt.Errorf("tan is undefined at %v degrees", args[0])
+		}
+		sin := degreeSine(args[0])
+		if sin == 0 {
+			// Avoid -0 from dividing by a negative cosine
+			return 0, nil
+		}
+		return sin / cos, nil
+	case "asin", "acos", "atan":
+		result, err := callFunction(name, args)
+		if err != nil {
+			return 0, err
+		}
+		return result * 180 / math.Pi, nil
+	default:
+		return callFunction(name, args)
+	}
+}
+
+// exactSines holds the sines of the multiples of 30 and 45 degrees in the
+// first quadrant, which converting to radians would round off
+var exactSines = map[float64]float64{
+	0:  0,
+	30: 0.5,
+	45: math.Sqrt2 / 2,
+	60: math.Sqrt(3) / 2,
+	90: 1,
+}
+
+// reduceDegrees reduces an angle in degrees to the range [0, 360)
+func reduceDegrees(degrees float64) float64 {
+	reduced := math.Mod(degrees, 360)
+	if reduced < 0 {
+		reduced += 360
+	}
+	return reduced
+}
+
+// degreeSine returns the sine of an angle in degrees. The angle is reduced to
+// the first quadrant before converting, so that multiples of 30 and 45
+// degrees give exact results such as sin(30) = 0.5 and sin(180) = 0.
+func degreeSine(degrees float64) float64 {
+	angle := reduceDegrees(degrees)
+	sign := 1.0
+	if angle >= 180 {
+		sign, angle = -1, angle-180
+	}
+	if angle > 90 {
+		angle = 180 - angle
+	}
+
+	if exact, ok := exactSines[angle]; ok {
+		if exact == 0 {
+			return 0
+		}
+		return sign * exact
+	}
+	return sign * math.Sin(angle*math.Pi/180)
+}
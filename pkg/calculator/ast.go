@@ -0,0 +1,195 @@
+package calculator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is a node of an expression's syntax tree as built by Parse
+type Node interface {
+	// String renders the node as expression text
+	String() string
+}
+
+// NumberNode is a numeric literal
+type NumberNode struct {
+	Value float64
+	Text  string // Literal as written, such as "0xFF" or "-2.5"
+}
+
+// IdentNode is a named constant or variable
+type IdentNode struct {
+	Name string
+}
+
+// BinaryNode is a binary operation. A postfix percent is represented as
+// division by 100, as it is evaluated.
+type BinaryNode struct {
+	Op    string
+	Left  Node
+	Right Node
+}
+
+// UnaryNode is the prefix complement ~x, the negation -x or the postfix factorial x!
+type UnaryNode struct {
+	Op      string
+	Operand Node
+}
+
+// GroupNode is a parenthesized expression
+type GroupNode struct {
+	Inner Node
+}
+
+// ConditionalNode is a conditional, cond ? then : else
+type ConditionalNode struct {
+	Cond Node
+	Then Node
+	Else Node
+}
+
+// CallNode is a function call
+type CallNode struct {
+	Name string
+	Args []Node
+}
+
+func (n *NumberNode) String() string { return n.Text }
+
+func (n *IdentNode) String() string { return n.Name }
+
+func (n *BinaryNode) String() string {
+	return n.Left.String() + " " + n.Op + " " + n.Right.String()
+}
+
+func (n *UnaryNode) String() string {
+	if n.Op == factorialOperator {
+		return n.Operand.String() + n.Op
+	}
+	return n.Op + n.Operand.String()
+}
+
+func (n *GroupNode) String() string { return "(" + n.Inner.String() + ")" }
+
+func (n *ConditionalNode) String() string {
+	return n.Cond.String() + " ? " + n.Then.String() + " : " + n.Else.String()
+}
+
+func (n *CallNode) String() string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.String()
+	}
+	return n.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Parse builds the syntax tree of a token sequence from Tokenize. Trivia
+// tokens are skipped. Names are not resolved, so unknown functions and
+// identifiers are only reported by Eval.
+func Parse(tokens []Token) (Node, error) {
+	significant := make([]Token, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Kind != TokenTrivia {
+			significant = append(significant, token)
+		}
+	}
+
+	if len(significant) == 0 {
+		return nil, ErrEmptyExpression
+	}
+
+	return reduceTokens(significant, nodeOperands)
+}
+
+// nodeOperands builds syntax tree nodes instead of values
+var nodeOperands = operandOps[Node]{
+	parse: func(token string) (Node, error) {
+		val, err := parseFloatOperand(token)
+		if err != nil {
+			return nil, err
+		}
+		return &NumberNode{Value: val, Text: token}, nil
+	},
+	apply: func(a, b Node, op string) (Node, error) {
+		// The complement's left operand is the implicit -1 added by reduceTokens
+		if op == complementOperator {
+			return &UnaryNode{Op: op, Operand: b}, nil
+		}
+		return &BinaryNode{Op: op, Left: a, Right: b}, nil
+	},
+	call: func(name string, args []Node) (Node, error) {
+		if name == factorialOperator {
+			return &UnaryNode{Op: name, Operand: args[0]}, nil
+		}
+		if name == conditionalOperator {
+			return &ConditionalNode{Cond: args[0], Then: args[1], Else: args[2]}, nil
+		}
+		return &CallNode{Name: name, Args: args}, nil
+	},
+	constant: func(name string) (Node, error) {
+		return &IdentNode{Name: name}, nil
+	},
+	group: func(inner Node) Node {
+		return &GroupNode{Inner: inner}
+	},
+	negate: func(operand Node) Node {
+		return &UnaryNode{Op: "-", Operand: operand}
+	},
+}
+
+// Eval evaluates a syntax tree with float64 arithmetic, giving the same
+// result as Evaluate on the source expression
+func Eval(node Node) (float64, error) {
+	switch n := node.(type) {
+	case *NumberNode:
+		return n.Value, nil
+	case *IdentNode:
+		return lookupConstant(n.Name)
+	case *GroupNode:
+		return Eval(n.Inner)
+	case *BinaryNode:
+		left, err := Eval(n.Left)
+		if err != nil {
+			return 0, err
+		}
+		right, err := Eval(n.Right)
+		if err != nil {
+			return 0, err
+		}
+		return applyOperator(left, right, n.Op)
+	case *UnaryNode:
+		operand, err := Eval(n.Operand)
+		if err != nil {
+			return 0, err
+		}
+		switch n.Op {
+		case factorialOperator:
+			return callFunction(n.Op, []float64{operand})
+		case "-":
+			return -operand, nil
+		}
+		return applyOperator(-1, operand, n.Op)
+	case *ConditionalNode:
+		// Only the chosen branch is evaluated
+		cond, err := Eval(n.Cond)
+		if err != nil {
+			return 0, err
+		}
+		if isNonzero(cond) {
+			return Eval(n.Then)
+		}
+		return Eval(n.Else)
+	case *CallNode:
+		args := make([]float64, len(n.Args))
+		for i, arg := range n.Args {
+			val, err := Eval(arg)
+			if err != nil {
+				return 0, err
+			}
+			args[i] = val
+		}
+		return callFunction(n.Name, args)
+	default:
+		return 0, fmt.Errorf("unknown node type: %T", node)
+	}
+}
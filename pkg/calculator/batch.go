@@ -0,0 +1,31 @@
+package calculator
+
+import "fmt"
+
+// BatchResult holds the outcome of evaluating one expression of a batch
+type BatchResult struct {
+	Input  string
+	Result float64
+	Err    error
+}
+
+// EvaluateBatch evaluates each expression independently. A bad expression
+// never aborts the batch: every entry carries its own result or error. The
+// returned error summarizes how many entries failed and is nil if none did.
+func EvaluateBatch(exprs []string) ([]BatchResult, error) {
+	results := make([]BatchResult, len(exprs))
+	failed := 0
+
+	for i, expr := range exprs {
+		result, err := Evaluate(expr)
+		results[i] = BatchResult{Input: expr, Result: result, Err: err}
+		if err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d expressions failed", failed, len(exprs))
+	}
+	return results, nil
+}
@@ -0,0 +1,76 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// xorOperator is bitwise exclusive or, spelled out because ^ is exponentiation
+	xorOperator = "xor"
+	// complementOperator is the prefix bitwise complement
+	complementOperator = "~"
+)
+
+// isBitwise checks if an operator works on the bits of integer operands
+func isBitwise(op string) bool {
+	switch op {
+	case "&", "|", xorOperator, "<<", ">>", complementOperator:
+		return true
+	default:
+		return false
+	}
+}
+
+// applyBitwise applies a bitwise operator to two float64 operands holding
+// integers. The complement ignores its left operand, which is always -1.
+func applyBitwise(a, b float64, operator string) (float64, error) {
+	x, err := bitwiseOperand(a, operator)
+	if err != nil {
+		return 0, err
+	}
+	y, err := bitwiseOperand(b, operator)
+	if err != nil {
+		return 0, err
+	}
+
+	switch operator {
+	case "&":
+		return float64(x & y), nil
+	case "|":
+		return float64(x | y), nil
+	case xorOperator:
+		return float64(x ^ y), nil
+	case "<<":
+		if y < 0 {
+			return 0, fmt.Errorf("negative shift count: %d", y)
+		}
+		// Bits shifted past the sign bit are lost; shifting back must recover x
+		shifted := x << y
+		if shifted>>y != x {
+			return 0, fmt.Errorf("shift overflow: %d << %d exceeds the int64 range", x, y)
+		}
+		return float64(shifted), nil
+	case ">>":
+		if y < 0 {
+			return 0, fmt.Errorf("negative shift count: %d", y)
+		}
+		return float64(x >> y), nil
+	case complementOperator:
+		return float64(^y), nil
+	default:
+		return 0, fmt.Errorf("unknown operator: %s", operator)
+	}
+}
+
+// bitwiseOperand converts an operand of a bitwise operator to int64,
+// rejecting fractions and values outside the int64 range
+func bitwiseOperand(val float64, operator string) (int64, error) {
+	if val != math.Trunc(val) {
+		return 0, fmt.Errorf("bitwise %s requires integer operands, got %v", operator, val)
+	}
+	if val < math.MinInt64 || val >= math.MaxInt64 {
+		return 0, fmt.Errorf("operand out of range for bitwise %s: %v", operator, val)
+	}
+	return int64(val), nil
+}
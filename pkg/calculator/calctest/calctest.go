@@ -0,0 +1,109 @@
+// Package calctest provides assertion helpers for tests that evaluate
+// calculator expressions.
+package calctest
+
+import (
+	"errors"
+
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+)
+
+// TB is the subset of testing.TB used by the helpers, so that they can be
+// exercised with a fake in their own tests
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// ErrKind classifies evaluation errors
+type ErrKind int
+
+const (
+	// ErrAny matches any error
+	ErrAny ErrKind = iota
+	// ErrDivisionByZero matches division or modulo by zero
+	ErrDivisionByZero
+	// ErrSyntax matches malformed expressions: invalid characters or tokens,
+	// missing operands and mismatched parentheses
+	ErrSyntax
+	// ErrUnknownName matches unknown functions and identifiers
+	ErrUnknownName
+	// ErrEmpty matches empty expressions
+	ErrEmpty
+	// ErrNone is the kind of a nil error
+	ErrNone
+)
+
+// String returns the name of the error kind
+func (k ErrKind) String() string {
+	switch k {
+	case ErrAny:
+		return "any error"
+	case ErrDivisionByZero:
+		return "division by zero"
+	case ErrSyntax:
+		return "syntax error"
+	case ErrUnknownName:
+		return "unknown name"
+	case ErrEmpty:
+		return "empty expression"
+	case ErrNone:
+		return "no error"
+	default:
+		return "unknown error kind"
+	}
+}
+
+// KindOf classifies an evaluation error by the calculator's sentinel errors
+// and error types. A nil error is ErrNone and an unclassified one ErrAny.
+func KindOf(err error) ErrKind {
+	var syntaxErr *calculator.SyntaxError
+	switch {
+	case err == nil:
+		return ErrNone
+	case errors.As(err, &syntaxErr):
+		return ErrSyntax
+	case errors.Is(err, calculator.ErrDivisionByZero):
+		return ErrDivisionByZero
+	case errors.Is(err, calculator.ErrEmptyExpression):
+		return ErrEmpty
+	case errors.Is(err, calculator.ErrUnknownFunction), errors.Is(err, calculator.ErrUnknownIdentifier):
+		return ErrUnknownName
+	default:
+		return ErrAny
+	}
+}
+
+// AssertEvaluates checks that expr evaluates to want within an absolute
+// tolerance tol, reporting a failure on t otherwise
+func AssertEvaluates(t TB, expr string, want float64, tol float64) bool {
+	t.Helper()
+
+	got, err := calculator.Evaluate(expr)
+	if err != nil {
+		t.Errorf("Evaluate(%q) returned error %q, want %v", expr, err, want)
+		return false
+	}
+	if !calculator.AlmostEqual(got, want, 0, tol) {
+		t.Errorf("Evaluate(%q) = %v, want %v (tolerance %v)", expr, got, want, tol)
+		return false
+	}
+	return true
+}
+
+// AssertErrors checks that evaluating expr fails with an error of wantKind,
+// reporting a failure on t otherwise
+func AssertErrors(t TB, expr string, wantKind ErrKind) bool {
+	t.Helper()
+
+	got, err := calculator.Evaluate(expr)
+	if err == nil {
+		t.Errorf("Evaluate(%q) = %v, want %s", expr, got, wantKind)
+		return false
+	}
+	if wantKind != ErrAny && KindOf(err) != wantKind {
+		t.Errorf("Evaluate(%q) returned error %q (%s), want %s", expr, err, KindOf(err), wantKind)
+		return false
+	}
+	return true
+}
@@ -3,9 +3,10 @@ package calculator
 import (
 	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
-	"unicode"
 )
 
 // Calculation represents a mathematical expression and its result
@@ -16,11 +17,17 @@ type Calculation struct {
 
 // Evaluate takes a mathematical expression string and returns the result
 func Evaluate(expression string) (float64, error) {
-	if strings.TrimSpace(expression) == "" {
-		return 0, errors.New("empty expression")
+	trimmed := strings.TrimSpace(expression)
+	if trimmed == "" {
+		return 0, ErrEmptyExpression
 	}
 
-	tokens, err := tokenize(expression)
+	// A lone number or constant needs no tokenizing or operator stacks
+	if val, ok := evaluateLoneOperand(trimmed); ok {
+		return val, nil
+	}
+
+	tokens, err := scanTokens(expression, false)
 	if err != nil {
 		return 0, err
 	}
@@ -37,209 +44,546 @@ func Evaluate(expression string) (float64, error) {
 	return result, nil
 }
 
-// tokenize converts an expression string into a slice of tokens
-func tokenize(expression string) ([]string, error) {
-	var tokens []string
-	var currentToken strings.Builder
-
-	// Keep track of whether the previous token was an operator or opening parenthesis
-	// This helps us identify negative numbers
-	previousTokenIsOperator := true
-
-	for _, char := range expression {
-		if unicode.IsSpace(char) {
-			// If we have a current token, add it to tokens
-			if currentToken.Len() > 0 {
-				tokens = append(tokens, currentToken.String())
-				currentToken.Reset()
-			}
-			continue
+// evaluateLoneOperand returns the value of an expression consisting of a
+// single decimal number or built-in constant, such as "42", "-1.5" or "pi".
+// ok is false for anything else, which is left to the full parser.
+func evaluateLoneOperand(expression string) (float64, bool) {
+	if val, ok := builtinConstants[expression]; ok {
+		return val, true
+	}
+	if !isPlainNumber(expression) {
+		return 0, false
+	}
+
+	val, err := strconv.ParseFloat(expression, 64)
+	if err != nil {
+		return 0, false
+	}
+	return val, true
+}
+
+// isPlainNumber checks if s is an optionally negative decimal number made of
+// digits and at most one point, the only literals ParseFloat and the
+// tokenizer agree on
+func isPlainNumber(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	digits, points := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			digits++
+		case s[i] == '.':
+			points++
+		default:
+			return false
 		}
+	}
+	return digits > 0 && points <= 1
+}
 
-		// Handle operators and parentheses
-		if isOperator(char) || char == '(' || char == ')' {
-			// If we have a current token, add it to tokens
-			if currentToken.Len() > 0 {
-				tokens = append(tokens, currentToken.String())
-				currentToken.Reset()
-			}
+// isOperator checks if a character is a mathematical operator, or the first
+// character of one like << and >>
+func isOperator(char rune) bool {
+	switch char {
+	case '+', '-', '*', '/', '%', '^', '&', '|', '~', '<', '>', '?', ':':
+		return true
+	default:
+		return false
+	}
+}
 
-			// Special handling for minus sign (could be negative number)
-			if char == '-' && previousTokenIsOperator {
-				// This is likely a negative number, don't add the minus sign yet
-				currentToken.WriteRune(char)
-			} else {
-				// Add the operator or parenthesis as a separate token
-				tokens = append(tokens, string(char))
-				previousTokenIsOperator = (char == '(' || isOperator(char))
-			}
-		} else if unicode.IsDigit(char) || char == '.' {
-			currentToken.WriteRune(char)
-			previousTokenIsOperator = false
-		} else {
-			return nil, fmt.Errorf("invalid character: %c", char)
+// parseAndEvaluate parses and evaluates tokens using the Shunting Yard algorithm approach
+func parseAndEvaluate(tokens []Token) (float64, error) {
+	return reduceTokens(tokens, floatOperands)
+}
+
+// floatOperands evaluates expressions with float64 arithmetic
+var floatOperands = operandOps[float64]{
+	parse:    parseFloatOperand,
+	apply:    applyOperator,
+	call:     callFunction,
+	constant: lookupConstant,
+	truthy:   isNonzero,
+}
+
+// parseFloatOperand parses a number token as a float64. Integer literals may
+// be written in hexadecimal, binary or octal with a 0x, 0b or 0o prefix.
+func parseFloatOperand(token string) (float64, error) {
+	if negative, digits, base, ok := radixLiteral(token); ok {
+		val, err := strconv.ParseUint(digits, base, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("%s literal out of range: %s exceeds 64 bits", radixNames[base], token)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("malformed %s literal: %s", radixNames[base], token)
+		}
+		if negative {
+			return -float64(val), nil
 		}
+		return float64(val), nil
 	}
 
-	// Add the last token if it exists
-	if currentToken.Len() > 0 {
-		tokens = append(tokens, currentToken.String())
+	val, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid token: %s", token)
 	}
+	return val, nil
+}
 
-	return tokens, nil
+// operandOps defines how reduceTokens builds operands of type T
+type operandOps[T any] struct {
+	parse    func(token string) (T, error)          // parses a number token
+	apply    func(a, b T, op string) (T, error)     // applies a binary operator
+	call     func(name string, args []T) (T, error) // calls a function or applies factorial
+	constant func(name string) (T, error)           // resolves a named constant
+	group    func(inner T) T                        // wraps a parenthesized operand; optional
+	truthy   func(cond T) bool                      // tests a condition; optional
+	negate   func(operand T) T                      // negates an operand; optional, else -1 * x
 }
 
-// isOperator checks if a character is a mathematical operator
-func isOperator(char rune) bool {
-	return char == '+' || char == '-' || char == '*' || char == '/'
+// negationOperator marks a prefix minus on the operator stack, where it
+// binds like * to the implicit -1 operand pushed before it
+const negationOperator = "neg"
+
+// parenFrame tracks an open parenthesis while reducing tokens
+type parenFrame struct {
+	call   bool // Whether the parenthesis opens a function's argument list
+	base   int  // Number of values on the stack when it was opened
+	commas int  // Number of argument separators seen so far
 }
 
-// parseAndEvaluate parses and evaluates tokens using the Shunting Yard algorithm approach
-func parseAndEvaluate(tokens []string) (float64, error) {
-	// For simplicity, we'll implement a recursive descent parser for basic arithmetic
-	// with operator precedence
+// stackBufferSize is the operand and operator stack capacity that needs no
+// heap allocation, enough for typical expressions
+const stackBufferSize = 16
+
+// reduceTokens runs the Shunting Yard algorithm over tokens, building operands
+// with ops as numbers are read and operators and functions are popped. It is
+// shared by the float64, exact integer and postfix modes. Malformed input is
+// reported as a *SyntaxError pointing at the offending token. When ops can
+// test conditions, the branch of a conditional that is not taken is checked
+// for syntax but not evaluated, so x != 0 ? 1/x : 0 does not fail for x = 0
+// and functions in it are never called. The algorithm
+// is a single loop over explicit stacks with no recursion, so long operator
+// chains and deep nesting are bounded by memory rather than the call stack.
+func reduceTokens[T any](tokens []Token, ops operandOps[T]) (T, error) {
+	var zero T
+	var valueBuffer [stackBufferSize]T
+	var operatorBuffer [stackBufferSize]Token
+	var frameBuffer [stackBufferSize]parenFrame
+	var branchBuffer [stackBufferSize]bool
+	values := valueBuffer[:0]
+	operators := operatorBuffer[:0]
+	frames := frameBuffer[:0]
+	// Whether each open conditional's current branch is not taken
+	branches := branchBuffer[:0]
+
+	// apply, call and constant evaluate through ops, except inside a branch
+	// that is not taken, where zero stands in for the value they would produce
+	skipped := func() bool {
+		return slices.Contains(branches, true)
+	}
+	apply := func(a, b T, op string) (T, error) {
+		if skipped() {
+			return ops.parse("0")
+		}
+		return ops.apply(a, b, op)
+	}
+	call := func(name string, args []T) (T, error) {
+		if skipped() {
+			return ops.parse("0")
+		}
+		return ops.call(name, args)
+	}
+	constant := func(name string) (T, error) {
+		if skipped() {
+			return ops.parse("0")
+		}
+		return ops.constant(name)
+	}
+
+	// applyConditional pops a completed conditional and its three operands
+	// and pushes the chosen branch
+	applyConditional := func(blame Token) error {
+		if len(values) < 3 {
+			return newSyntaxError(blame, "invalid expression")
+		}
+		operators = operators[:len(operators)-1]
+		branches = branches[:len(branches)-1]
+
+		args := slices.Clone(values[len(values)-3:])
+		result, err := call(conditionalOperator, args)
+		if err != nil {
+			return err
+		}
+		values = append(values[:len(values)-3], result)
+		return nil
+	}
+
+	// applyTop pops the top operator and its two operands and pushes the
+	// result. A missing operand is blamed on the given token.
+	applyTop := func(blame Token) error {
+		op := operators[len(operators)-1]
+		switch op.Value {
+		case conditionalOperator:
+			return newSyntaxError(op, "missing : in conditional")
+		case branchSeparator:
+			return applyConditional(blame)
+		}
 
-	// Convert to reverse polish notation and evaluate
-	values := []float64{}
-	operators := []string{}
+		if len(values) < 2 {
+			return newSyntaxError(blame, "invalid expression")
+		}
+		operators = operators[:len(operators)-1]
+
+		val2 := values[len(values)-1]
+		values = values[:len(values)-1]
+		val1 := values[len(values)-1]
+		values = values[:len(values)-1]
+
+		var result T
+		var err error
+		switch {
+		case op.Value == negationOperator && ops.negate != nil:
+			result = ops.negate(val2)
+		case op.Value == negationOperator:
+			result, err = apply(val1, val2, "*")
+		default:
+			result, err = apply(val1, val2, op.Value)
+		}
+		if err != nil {
+			return err
+		}
+
+		values = append(values, result)
+		return nil
+	}
+
+	// closeArgument applies operators back to the innermost "(" and checks
+	// that the argument just ended left exactly one new value
+	closeArgument := func(blame Token) error {
+		for len(operators) > 0 && operators[len(operators)-1].Value != "(" {
+			if err := applyTop(blame); err != nil {
+				return err
+			}
+		}
+		if len(operators) == 0 || len(frames) == 0 {
+			return newSyntaxError(blame, "mismatched parentheses")
+		}
+
+		frame := frames[len(frames)-1]
+		if len(values)-frame.base != frame.commas+1 {
+			return newSyntaxError(blame, "invalid expression")
+		}
+		return nil
+	}
+
+	// pushOperator applies stacked operators that bind at least as tightly
+	// as op, then stacks op
+	pushOperator := func(op Token) error {
+		for len(operators) > 0 && operators[len(operators)-1].Value != "(" &&
+			hasPrecedence(operators[len(operators)-1].Value, op.Value) {
+			if err := applyTop(op); err != nil {
+				return err
+			}
+		}
+		operators = append(operators, op)
+		return nil
+	}
 
 	for i := 0; i < len(tokens); i++ {
-		token := tokens[i]
+		token := tokens[i].Value
+
+		// Juxtaposed operands such as 2(3+4) or 2pi are multiplied
+		if i > 0 && impliesMultiplication(tokens[i-1], tokens[i]) {
+			implicit := Token{Kind: TokenOperator, Value: "*", Pos: tokens[i].Pos}
+			if err := pushOperator(implicit); err != nil {
+				return zero, err
+			}
+		}
 
 		// If token is a number, push it to stack for numbers
-		if isNumber(token) {
-			val, err := strconv.ParseFloat(token, 64)
+		if tokens[i].Kind == TokenNumber {
+			val, err := ops.parse(token)
+			if err != nil {
+				return zero, newSyntaxError(tokens[i], err.Error())
+			}
+			values = append(values, val)
+		} else if tokens[i].Kind == TokenIdent {
+			// An identifier followed by an argument list is a function call,
+			// otherwise it names a constant
+			if i+1 < len(tokens) && tokens[i+1].Kind == TokenLeftParen {
+				operators = append(operators, tokens[i])
+				continue
+			}
+
+			val, err := constant(token)
 			if err != nil {
-				return 0, fmt.Errorf("invalid number: %s", token)
+				return zero, err
 			}
 			values = append(values, val)
 		} else if token == "(" {
-			operators = append(operators, token)
+			call := i > 0 && tokens[i-1].Kind == TokenIdent
+			frames = append(frames, parenFrame{call: call, base: len(values)})
+			operators = append(operators, tokens[i])
+		} else if tokens[i].Kind == TokenComma {
+			// A comma ends one function argument and starts the next
+			if len(frames) == 0 || !frames[len(frames)-1].call {
+				return zero, newSyntaxError(tokens[i], "unexpected comma")
+			}
+			if err := closeArgument(tokens[i]); err != nil {
+				return zero, err
+			}
+			frames[len(frames)-1].commas++
 		} else if token == ")" {
-			// Process until we find a matching opening parenthesis
-			for len(operators) > 0 && operators[len(operators)-1] != "(" {
-				if len(values) < 2 {
-					return 0, errors.New("invalid expression")
+			// An empty argument list is reported below as a missing argument
+			empty := i > 0 && tokens[i-1].Kind == TokenLeftParen && len(frames) > 0 && frames[len(frames)-1].call
+			if !empty {
+				if err := closeArgument(tokens[i]); err != nil {
+					return zero, err
 				}
+			}
+
+			// Pop the opening parenthesis
+			frame := frames[len(frames)-1]
+			frames = frames[:len(frames)-1]
+			operators = operators[:len(operators)-1]
 
-				op := operators[len(operators)-1]
+			// Call the function owning this argument list
+			if frame.call {
+				name := operators[len(operators)-1].Value
 				operators = operators[:len(operators)-1]
 
-				val2 := values[len(values)-1]
-				values = values[:len(values)-1]
-				val1 := values[len(values)-1]
-				values = values[:len(values)-1]
+				if empty {
+					return zero, newSyntaxError(tokens[i], fmt.Sprintf("missing argument for function %s", name))
+				}
 
-				result, err := applyOperator(val1, val2, op)
+				// Pass a copy so the stack buffer does not escape to the heap
+				result, err := call(name, slices.Clone(values[frame.base:]))
 				if err != nil {
-					return 0, err
+					return zero, err
 				}
-
-				values = append(values, result)
+				values = append(values[:frame.base], result)
+			} else if ops.group != nil {
+				values[len(values)-1] = ops.group(values[len(values)-1])
 			}
-
-			// Pop the opening parenthesis
-			if len(operators) > 0 {
-				operators = operators[:len(operators)-1]
-			} else {
-				return 0, errors.New("mismatched parentheses")
+		} else if token == factorialOperator {
+			// Postfix factorial applies to the operand just read
+			if len(values) < 1 {
+				return zero, newSyntaxError(tokens[i], "invalid expression")
 			}
-		} else if isOperatorString(token) {
-			// Process operators according to precedence
-			for len(operators) > 0 && operators[len(operators)-1] != "(" &&
-				hasPrecedence(operators[len(operators)-1], token) {
-
-				if len(values) < 2 {
-					return 0, errors.New("invalid expression")
-				}
 
-				op := operators[len(operators)-1]
-				operators = operators[:len(operators)-1]
-
-				val2 := values[len(values)-1]
-				values = values[:len(values)-1]
-				val1 := values[len(values)-1]
-				values = values[:len(values)-1]
+			result, err := call(factorialOperator, slices.Clone(values[len(values)-1:]))
+			if err != nil {
+				return zero, err
+			}
+			values[len(values)-1] = result
+		} else if token == "%" && isPostfixPosition(tokens, i) {
+			// Postfix percent divides the operand just read by 100
+			if len(values) < 1 {
+				return zero, newSyntaxError(tokens[i], "invalid expression")
+			}
 
-				result, err := applyOperator(val1, val2, op)
-				if err != nil {
-					return 0, err
+			hundred, err := ops.parse("100")
+			if err != nil {
+				return zero, err
+			}
+			result, err := apply(values[len(values)-1], hundred, "/")
+			if err != nil {
+				return zero, err
+			}
+			values[len(values)-1] = result
+		} else if token == complementOperator {
+			// Complement is a prefix operator; it is applied as -1 ~ x so that
+			// it binds like a binary operator to the operand that follows
+			minusOne, err := ops.parse("-1")
+			if err != nil {
+				return zero, err
+			}
+			values = append(values, minusOne)
+			operators = append(operators, tokens[i])
+		} else if token == "-" && expectsOperand(tokens, i) {
+			// A minus with no operand before it negates what follows. It binds
+			// like * to an implicit -1, so -pi ^ 2 is -(pi ^ 2) and 2 * -pi is
+			// 2 * (-pi).
+			minusOne, err := ops.parse("-1")
+			if err != nil {
+				return zero, err
+			}
+			values = append(values, minusOne)
+			operators = append(operators, Token{Kind: TokenOperator, Value: negationOperator, Pos: tokens[i].Pos})
+		} else if token == conditionalOperator {
+			// The condition is complete, since nothing binds looser than ?
+			if err := pushOperator(tokens[i]); err != nil {
+				return zero, err
+			}
+			taken := true
+			if ops.truthy != nil && len(values) > 0 {
+				taken = ops.truthy(values[len(values)-1])
+			}
+			branches = append(branches, !taken)
+		} else if token == branchSeparator {
+			// Finish the then branch and start the else branch in its place
+			for len(operators) > 0 && operators[len(operators)-1].Value != conditionalOperator &&
+				operators[len(operators)-1].Value != "(" {
+				if err := applyTop(tokens[i]); err != nil {
+					return zero, err
 				}
+			}
+			if len(operators) == 0 || operators[len(operators)-1].Value != conditionalOperator {
+				return zero, newSyntaxError(tokens[i], "unexpected : without ?")
+			}
+			operators[len(operators)-1] = tokens[i]
+			if ops.truthy != nil {
+				branches[len(branches)-1] = !branches[len(branches)-1]
+			}
+		} else if isOperatorString(token) {
+			// 1 < 2 < 3 reads as a range check but would compare a 0 or 1
+			// result, so chained comparisons need explicit parentheses
+			if isComparison(token) && hasOpenComparison(operators) {
+				return zero, newSyntaxError(tokens[i], "chained comparison, use parentheses")
+			}
 
-				values = append(values, result)
+			// Process operators according to precedence
+			if err := pushOperator(tokens[i]); err != nil {
+				return zero, err
 			}
-			operators = append(operators, token)
 		} else {
-			return 0, fmt.Errorf("invalid token: %s", token)
+			return zero, newSyntaxError(tokens[i], fmt.Sprintf("invalid token: %s", token))
 		}
 	}
 
 	// Process remaining operators
 	for len(operators) > 0 {
-		if operators[len(operators)-1] == "(" || operators[len(operators)-1] == ")" {
-			return 0, errors.New("mismatched parentheses")
-		}
-
-		if len(values) < 2 {
-			return 0, errors.New("invalid expression")
+		top := operators[len(operators)-1]
+		if top.Value == "(" || top.Value == ")" {
+			return zero, newSyntaxError(top, "mismatched parentheses")
 		}
 
-		op := operators[len(operators)-1]
-		operators = operators[:len(operators)-1]
-
-		val2 := values[len(values)-1]
-		values = values[:len(values)-1]
-		val1 := values[len(values)-1]
-		values = values[:len(values)-1]
-
-		result, err := applyOperator(val1, val2, op)
-		if err != nil {
-			return 0, err
+		if err := applyTop(top); err != nil {
+			return zero, err
 		}
-
-		values = append(values, result)
 	}
 
 	if len(values) != 1 {
-		return 0, errors.New("invalid expression")
+		return zero, newSyntaxError(tokens[len(tokens)-1], "invalid expression")
 	}
 
 	return values[0], nil
 }
 
-// isNumber checks if a string represents a number
-func isNumber(s string) bool {
-	_, err := strconv.ParseFloat(s, 64)
-	return err == nil
+// impliesMultiplication checks if next directly follows an operand ending in
+// prev with no operator between them, as in 2(3+4), (2)(3), 2pi or 3 sqrt(4).
+// The implied multiplication binds exactly like an explicit *, so 1/2(3) is
+// (1/2)*3 = 1.5. An identifier followed by "(" remains a function call.
+func impliesMultiplication(prev, next Token) bool {
+	if prev.Kind != TokenNumber && prev.Kind != TokenRightParen {
+		return false
+	}
+	return next.Kind == TokenLeftParen || next.Kind == TokenIdent
 }
 
-// isOperatorString checks if a string is an operator
-func isOperatorString(s string) bool {
-	return s == "+" || s == "-" || s == "*" || s == "/"
+// expectsOperand checks if the token at index i starts an operand: it opens
+// the expression, a parenthesis or an argument, or follows an operator that
+// takes an operand after it
+func expectsOperand(tokens []Token, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := tokens[i-1]
+	switch prev.Kind {
+	case TokenLeftParen, TokenComma, TokenAssign:
+		return true
+	case TokenOperator:
+		if prev.Value == factorialOperator {
+			return false
+		}
+		return prev.Value != "%" || !isPostfixPosition(tokens, i-1)
+	default:
+		return false
+	}
 }
 
-// hasPrecedence checks if op1 has higher or equal precedence than op2
-func hasPrecedence(op1, op2 string) bool {
-	// Parentheses have special handling and should not be compared directly
-	if op1 == "(" || op1 == ")" || op2 == "(" || op2 == ")" {
+// isPostfixPosition checks if the operator at index i has no operand after it,
+// which makes % a postfix percent rather than modulo. That is the case at the
+// end of the expression and before a closing parenthesis or another operator,
+// so "200 + 10%" is 200 + 0.1 while "17 % 5" is modulo.
+func isPostfixPosition(tokens []Token, i int) bool {
+	if i+1 >= len(tokens) {
+		return true
+	}
+	next := tokens[i+1]
+	if next.Value == complementOperator {
 		return false
 	}
+	return next.Kind == TokenOperator || next.Kind == TokenRightParen
+}
 
-	// Multiplication and division have higher precedence than addition and subtraction
-	if (op1 == "*" || op1 == "/") && (op2 == "+" || op2 == "-") {
-		return true
+// isOperatorString checks if a string is an operator
+func isOperatorString(s string) bool {
+	return operatorPrecedence(s) > 0
+}
+
+// isMultiplicative checks if an operator binds at multiplication precedence
+func isMultiplicative(op string) bool {
+	return op == "*" || op == "/" || op == "//" || op == "%"
+}
+
+// operatorPrecedence returns the binding strength of an operator, or 0 if the
+// string is not an operator. Conditionals bind loosest, then comparisons,
+// then the bitwise operators, then arithmetic, so 1 + 2 << 3 is
+// (1 + 2) << 3, x & 0xF | 1 is (x & 0xF) | 1 and 1 + 1 == 2 compares the sum.
+func operatorPrecedence(op string) int {
+	switch {
+	case op == conditionalOperator || op == branchSeparator:
+		return 1
+	case isComparison(op):
+		return 2
+	case op == "|":
+		return 3
+	case op == xorOperator:
+		return 4
+	case op == "&":
+		return 5
+	case op == "<<" || op == ">>":
+		return 6
+	case op == "+" || op == "-":
+		return 7
+	case isMultiplicative(op) || op == negationOperator:
+		return 8
+	case op == complementOperator:
+		return 9
+	case op == "^":
+		return 10
+	default:
+		return 0
 	}
+}
 
-	// If op1 and op2 have the same precedence, we return true for left associativity
-	if (op1 == "+" || op1 == "-") && (op2 == "+" || op2 == "-") {
+// isRightAssociative checks if an operator groups from the right, so that
+// 2 ^ 3 ^ 2 is 2 ^ (3 ^ 2), ~~x is ~(~x) and a ? b : c ? d : e is
+// a ? b : (c ? d : e)
+func isRightAssociative(op string) bool {
+	switch op {
+	case "^", complementOperator, conditionalOperator, branchSeparator:
 		return true
+	default:
+		return false
 	}
-	if (op1 == "*" || op1 == "/") && (op2 == "*" || op2 == "/") {
-		return true
+}
+
+// hasPrecedence checks if op1 on the operator stack should be applied before
+// the incoming op2: it binds tighter, or equally tight and op2 is left associative
+func hasPrecedence(op1, op2 string) bool {
+	// Parentheses have special handling and should not be compared directly
+	if op1 == "(" || op1 == ")" || op2 == "(" || op2 == ")" {
+		return false
 	}
 
-	// Otherwise, op2 has higher precedence
-	return false
+	p1, p2 := operatorPrecedence(op1), operatorPrecedence(op2)
+	if p1 != p2 {
+		return p1 > p2
+	}
+	return !isRightAssociative(op2)
 }
 
 // applyOperator applies an operator to two operands
@@ -253,10 +597,33 @@ func applyOperator(a, b float64, operator string) (float64, error) {
 		return a * b, nil
 	case "/":
 		if b == 0 {
-			return 0, errors.New("division by zero")
+			return 0, ErrDivisionByZero
 		}
 		return a / b, nil
+	case "//":
+		if b == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return math.Floor(a / b), nil
+	case "%":
+		if b == 0 {
+			return 0, ErrDivisionByZero
+		}
+		// Floored modulo: the result takes the sign of the divisor, matching //
+		remainder := math.Mod(a, b)
+		if remainder != 0 && (remainder < 0) != (b < 0) {
+			remainder += b
+		}
+		return remainder, nil
+	case "^":
+		return math.Pow(a, b), nil
 	default:
+		if isBitwise(operator) {
+			return applyBitwise(a, b, operator)
+		}
+		if isComparison(operator) {
+			return compareFloats(a, b, operator)
+		}
 		return 0, fmt.Errorf("unknown operator: %s", operator)
 	}
 }
@@ -126,7 +126,7 @@ func TestCalculatorCoverageVisual(t *testing.T) {
 		{"operator_precedence", "2+3*4-1", 13.0, "precedence"},
 		{"nested_parentheses", "((2+3)*4)+1", 21.0, "complex"},
 		{"division_by_zero", "5/0", "error", "error_handling"},
-		{"invalid_operator", "5&3", "error", "error_handling"},
+		{"invalid_operator", "5$3", "error", "error_handling"},
 		{"unmatched_parentheses", "(2+3", "error", "error_handling"},
 	}
 
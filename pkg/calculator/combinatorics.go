@@ -0,0 +1,139 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// factorialOperator is the postfix factorial, as in 5!
+const factorialOperator = "!"
+
+// maxFactorial is the largest n whose factorial fits in a float64; 171! overflows
+const maxFactorial = 170
+
+// maxIntFactorial bounds factorials in integer mode to keep results a sensible size
+const maxIntFactorial = 1 << 14
+
+// factorial returns n! for a non-negative integer n
+func factorial(n float64) (float64, error) {
+	if err := checkCountingArgument("factorial", n); err != nil {
+		return 0, err
+	}
+	if n > maxFactorial {
+		return 0, fmt.Errorf("factorial overflow: %v! exceeds the float64 range", n)
+	}
+
+	result := 1.0
+	for i := 2.0; i <= n; i++ {
+		result *= i
+	}
+	return result, nil
+}
+
+// combinations returns nCr(n, k), the number of ways to choose k of n items
+// ignoring order. It is 0 when k > n.
+func combinations(args []float64) (float64, error) {
+	n, k := args[0], args[1]
+	if err := checkCountingArguments("nCr", n, k); err != nil {
+		return 0, err
+	}
+	if k > n {
+		return 0, nil
+	}
+
+	// Each partial product is itself a binomial coefficient, so it stays exact
+	// while it fits in the mantissa. The counter is an integer because adding
+	// 1 to a float64 above 2^53 no longer changes it; every factor is at
+	// least 2, so the product overflows long before the count gets that far.
+	smaller := math.Min(k, n-k)
+	result := 1.0
+	for i := int64(1); float64(i) <= smaller; i++ {
+		result = result * (n - smaller + float64(i)) / float64(i)
+		if math.IsInf(result, 0) {
+			return 0, fmt.Errorf("nCr overflow: nCr(%v, %v) exceeds the float64 range", n, k)
+		}
+	}
+	return result, nil
+}
+
+// permutations returns nPr(n, k), the number of ordered arrangements of k of
+// n items. It is 0 when k > n.
+func permutations(args []float64) (float64, error) {
+	n, k := args[0], args[1]
+	if err := checkCountingArguments("nPr", n, k); err != nil {
+		return 0, err
+	}
+	if k > n {
+		return 0, nil
+	}
+
+	// Multiply n, n-1, ... exactly k times, counting with an integer since
+	// n - k + 1 can be too large for a float64 counter to step through
+	result := 1.0
+	for i := int64(0); float64(i) < k; i++ {
+		result *= n - float64(i)
+		if math.IsInf(result, 0) {
+			return 0, fmt.Errorf("nPr overflow: nPr(%v, %v) exceeds the float64 range", n, k)
+		}
+	}
+	return result, nil
+}
+
+// checkCountingArguments checks that every argument is a non-negative integer
+func checkCountingArguments(name string, args ...float64) error {
+	for _, arg := range args {
+		if err := checkCountingArgument(name, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCountingArgument checks that a factorial or combinatorics argument is
+// a non-negative integer
+func checkCountingArgument(name string, val float64) error {
+	if val < 0 {
+		return fmt.Errorf("%s of negative number: %v", name, val)
+	}
+	if val != math.Trunc(val) || math.IsInf(val, 0) {
+		return fmt.Errorf("%s requires a non-negative integer, got %v", name, val)
+	}
+	return nil
+}
+
+// callIntCounting computes factorial, nCr and nPr exactly in integer mode.
+// ok is false for any other function.
+func callIntCounting(name string, args []*big.Int) (result *big.Int, ok bool, err error) {
+	switch name {
+	case factorialOperator, "nCr", "nPr":
+	default:
+		return nil, false, nil
+	}
+
+	for _, arg := range args {
+		if arg.Sign() < 0 {
+			label := name
+			if name == factorialOperator {
+				label = "factorial"
+			}
+			return nil, true, fmt.Errorf("%s of negative number: %s", label, arg)
+		}
+		if !arg.IsInt64() || arg.Int64() > maxIntFactorial {
+			return nil, true, fmt.Errorf("argument too large for %s: %s", name, arg)
+		}
+	}
+
+	if name == factorialOperator {
+		return new(big.Int).MulRange(1, args[0].Int64()), true, nil
+	}
+
+	n, k := args[0].Int64(), args[1].Int64()
+	if k > n {
+		return new(big.Int), true, nil
+	}
+	if name == "nCr" {
+		return new(big.Int).Binomial(n, k), true, nil
+	}
+	return new(big.Int).MulRange(n-k+1, n), true, nil
+}
@@ -0,0 +1,112 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// AlmostEqual reports whether a and b are equal within a relative tolerance
+// (scaled by the larger magnitude) or an absolute tolerance, whichever is looser.
+// The absolute tolerance matters for values near zero, where any relative
+// tolerance shrinks to nothing.
+func AlmostEqual(a, b, relTol, absTol float64) bool {
+	// Handle exact equality, including matching infinities
+	if a == b {
+		return true
+	}
+
+	// NaN is never equal and an infinity only equals itself
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+
+	diff := math.Abs(a - b)
+	return diff <= math.Max(relTol*math.Max(math.Abs(a), math.Abs(b)), absTol)
+}
+
+// comparisonTolerance is the relative tolerance within which the comparison
+// operators treat float64 operands as equal, so that 0.1 + 0.2 == 0.3 holds
+// despite rounding error
+const comparisonTolerance = 1e-9
+
+// comparisonAbsTolerance is the absolute tolerance floor for comparisons. It
+// is machine epsilon, so that rounding error in results near zero such as
+// 1 - 0.9 - 0.1 still compares equal to 0 while small values such as 1e-10
+// do not.
+const comparisonAbsTolerance = 0x1p-52
+
+// isComparison checks if an operator compares its operands
+func isComparison(op string) bool {
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	default:
+		return false
+	}
+}
+
+// hasOpenComparison checks if a comparison is waiting on the operator stack
+// above the innermost "(", meaning another comparison would chain onto it
+func hasOpenComparison(operators []Token) bool {
+	for i := len(operators) - 1; i >= 0 && operators[i].Value != "("; i-- {
+		if isComparison(operators[i].Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareFloats applies a comparison operator to two float64 operands,
+// returning 1 when it holds and 0 otherwise. Operands within
+// comparisonTolerance of each other, relative to the larger, are equal, so <
+// and > only hold for a clear difference. Comparisons involving NaN are
+// false, except !=.
+func compareFloats(a, b float64, operator string) (float64, error) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return float64(boolToInt(operator == "!=")), nil
+	}
+
+	order := 1
+	switch {
+	case AlmostEqual(a, b, comparisonTolerance, comparisonAbsTolerance):
+		order = 0
+	case a < b:
+		order = -1
+	}
+
+	holds, err := comparisonHolds(order, operator)
+	if err != nil {
+		return 0, err
+	}
+	return float64(boolToInt(holds)), nil
+}
+
+// comparisonHolds checks whether a comparison operator holds for operands
+// ordered as order: negative, zero or positive as the left operand is less
+// than, equal to or greater than the right
+func comparisonHolds(order int, operator string) (bool, error) {
+	switch operator {
+	case "==":
+		return order == 0, nil
+	case "!=":
+		return order != 0, nil
+	case "<":
+		return order < 0, nil
+	case "<=":
+		return order <= 0, nil
+	case ">":
+		return order > 0, nil
+	case ">=":
+		return order >= 0, nil
+	default:
+		return false, fmt.Errorf("unknown operator: %s", operator)
+	}
+}
+
+// boolToInt returns 1 for true and 0 for false, the results of comparisons
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,23 @@
+package calculator
+
+const (
+	// conditionalOperator starts a conditional, cond ? then : else. In
+	// postfix it takes the three operands, as in "c a b ?".
+	conditionalOperator = "?"
+	// branchSeparator separates the branches of a conditional
+	branchSeparator = ":"
+)
+
+// conditional returns the then or else argument of a conditional, choosing
+// then when the condition is nonzero
+func conditional(args []float64) float64 {
+	if args[0] != 0 {
+		return args[1]
+	}
+	return args[2]
+}
+
+// isNonzero reports whether a float64 condition is true
+func isNonzero(val float64) bool {
+	return val != 0
+}
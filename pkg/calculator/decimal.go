@@ -0,0 +1,285 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Decimal digits of pi and e used by EvaluateDecimal, accurate to 100 places
+const (
+	decimalPi = "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679"
+	decimalE  = "2.7182818284590452353602874713526624977572470936999595749669676277240766303535475945713821785251664274"
+)
+
+// EvaluateDecimal evaluates an expression with big.Float arithmetic at prec
+// bits of mantissa and returns the result as a decimal string. The result is
+// rounded to the decimal digits that prec resolves, less a few guard digits,
+// so that inputs such as 0.1 + 0.2 come out as 0.3 instead of carrying
+// float64 drift. Exponents must be integers, and of the functions only
+// factorial, sqrt, min, max, sum and avg are supported. The constants pi and e are
+// accurate to 100 decimal places.
+func EvaluateDecimal(expression string, prec uint) (string, error) {
+	if prec == 0 {
+		return "", errors.New("precision must be positive")
+	}
+	if strings.TrimSpace(expression) == "" {
+		return "", ErrEmptyExpression
+	}
+
+	tokens, err := scanTokens(expression, false)
+	if err != nil {
+		return "", err
+	}
+
+	if len(tokens) == 0 {
+		return "", errors.New("invalid expression")
+	}
+
+	result, err := reduceTokens(tokens, decimalOperands(prec))
+	if err != nil {
+		return "", err
+	}
+	return formatDecimal(result, prec), nil
+}
+
+// decimalGuardDigits are the decimal digits dropped from the display to hide
+// rounding error accumulated in the last bits of the mantissa
+const decimalGuardDigits = 3
+
+// formatDecimal formats a result with the decimal digits prec resolves
+func formatDecimal(val *big.Float, prec uint) string {
+	digits := int(float64(prec)*math.Log10(2)) - decimalGuardDigits
+	if digits < 1 {
+		digits = 1
+	}
+	return val.Text('g', digits)
+}
+
+// decimalOperands evaluates expressions with big.Float arithmetic at prec bits
+func decimalOperands(prec uint) operandOps[*big.Float] {
+	newFloat := func() *big.Float {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return operandOps[*big.Float]{
+		parse: func(token string) (*big.Float, error) {
+			if negative, digits, base, ok := radixLiteral(token); ok {
+				val, ok := new(big.Int).SetString(digits, base)
+				if !ok {
+					return nil, fmt.Errorf("malformed %s literal: %s", radixNames[base], token)
+				}
+				if negative {
+					val.Neg(val)
+				}
+				return newFloat().SetInt(val), nil
+			}
+
+			val, ok := newFloat().SetString(token)
+			if !ok {
+				return nil, fmt.Errorf("invalid token: %s", token)
+			}
+			return checkDecimalRange(val, nil)
+		},
+		apply: func(a, b *big.Float, operator string) (*big.Float, error) {
+			return checkDecimalRange(applyDecimalOperator(a, b, operator, newFloat))
+		},
+		call: func(name string, args []*big.Float) (*big.Float, error) {
+			return checkDecimalRange(callDecimalFunction(name, args, newFloat))
+		},
+		constant: func(name string) (*big.Float, error) {
+			switch name {
+			case "pi":
+				val, _ := newFloat().SetString(decimalPi)
+				return val, nil
+			case "e":
+				val, _ := newFloat().SetString(decimalE)
+				return val, nil
+			}
+			return nil, fmt.Errorf("%w: %s", ErrUnknownIdentifier, name)
+		},
+		truthy: func(cond *big.Float) bool {
+			return cond.Sign() != 0
+		},
+	}
+}
+
+// errDecimalOverflow reports a result beyond the exponent range of big.Float
+var errDecimalOverflow = errors.New("overflow: result exceeds the decimal range")
+
+// checkDecimalRange rejects infinite results. big.Float saturates to
+// infinity on overflow and panics when a later operation combines
+// infinities, so no infinity may become an operand.
+func checkDecimalRange(val *big.Float, err error) (*big.Float, error) {
+	if err != nil {
+		return nil, err
+	}
+	if val.IsInf() {
+		return nil, errDecimalOverflow
+	}
+	return val, nil
+}
+
+// applyDecimalOperator applies an operator to two big.Float operands
+func applyDecimalOperator(a, b *big.Float, operator string, newFloat func() *big.Float) (*big.Float, error) {
+	switch operator {
+	case "+":
+		return newFloat().Add(a, b), nil
+	case "-":
+		return newFloat().Sub(a, b), nil
+	case "*":
+		return newFloat().Mul(a, b), nil
+	case "/":
+		if b.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return newFloat().Quo(a, b), nil
+	case "//", "%":
+		if b.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		quotient := decimalFloor(newFloat().Quo(a, b), newFloat)
+		if operator == "//" {
+			return quotient, nil
+		}
+		// Floored modulo: a - b * floor(a / b) takes the sign of the divisor
+		return newFloat().Sub(a, newFloat().Mul(b, quotient)), nil
+	case "^":
+		return decimalPow(a, b, newFloat)
+	default:
+		if isBitwise(operator) {
+			return applyDecimalBitwise(a, b, operator, newFloat)
+		}
+		if isComparison(operator) {
+			holds, err := comparisonHolds(a.Cmp(b), operator)
+			if err != nil {
+				return nil, err
+			}
+			return newFloat().SetInt64(boolToInt(holds)), nil
+		}
+		return nil, fmt.Errorf("unknown operator: %s", operator)
+	}
+}
+
+// decimalFloor rounds a big.Float towards negative infinity
+func decimalFloor(val *big.Float, newFloat func() *big.Float) *big.Float {
+	truncated, accuracy := val.Int(nil)
+	// Int truncates towards zero, which rounds negative values up
+	if val.Sign() < 0 && accuracy != big.Exact {
+		truncated.Sub(truncated, big.NewInt(1))
+	}
+	return newFloat().SetInt(truncated)
+}
+
+// decimalPow raises a to an integer power by repeated squaring
+func decimalPow(a, b *big.Float, newFloat func() *big.Float) (*big.Float, error) {
+	if !b.IsInt() {
+		return nil, fmt.Errorf("non-integer exponent not supported in decimal mode: %s", b.Text('g', 10))
+	}
+	exponent, _ := b.Int(nil)
+	if !exponent.IsInt64() || exponent.Int64() > math.MaxInt32 || exponent.Int64() < -math.MaxInt32 {
+		return nil, fmt.Errorf("exponent out of range: %s", exponent)
+	}
+
+	n := exponent.Int64()
+	negative := n < 0
+	if negative {
+		if a.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		n = -n
+	}
+
+	// |a| is at least 2^(exp-1), so a power whose binary exponent exceeds
+	// MaxExp is certain to overflow and is rejected before multiplying
+	if exp := a.MantExp(nil); exp > 1 && int64(exp-1)*n > big.MaxExp {
+		return nil, errDecimalOverflow
+	}
+
+	result := newFloat().SetInt64(1)
+	base := newFloat().Set(a)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result.Mul(result, base)
+		}
+		if n > 1 {
+			base.Mul(base, base)
+		}
+	}
+
+	if negative {
+		result = newFloat().Quo(newFloat().SetInt64(1), result)
+	}
+	return checkDecimalRange(result, nil)
+}
+
+// applyDecimalBitwise applies a bitwise operator to big.Float operands
+// holding integers, using exact integer arithmetic
+func applyDecimalBitwise(a, b *big.Float, operator string, newFloat func() *big.Float) (*big.Float, error) {
+	if !a.IsInt() || !b.IsInt() {
+		return nil, fmt.Errorf("bitwise %s requires integer operands", operator)
+	}
+	x, _ := a.Int(nil)
+	y, _ := b.Int(nil)
+
+	result, err := applyIntOperator(x, y, operator)
+	if err != nil {
+		return nil, err
+	}
+	return newFloat().SetInt(result), nil
+}
+
+// callDecimalFunction applies the functions supported in decimal mode
+func callDecimalFunction(name string, args []*big.Float, newFloat func() *big.Float) (*big.Float, error) {
+	minArgs, maxArgs, ok := functionArity(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFunction, name)
+	}
+	if err := checkArgumentCount(name, minArgs, maxArgs, len(args)); err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case conditionalOperator:
+		if args[0].Sign() != 0 {
+			return args[1], nil
+		}
+		return args[2], nil
+	case factorialOperator:
+		n, accuracy := args[0].Int(nil)
+		if accuracy != big.Exact {
+			return nil, fmt.Errorf("factorial requires a non-negative integer, got %s", args[0].Text('g', 10))
+		}
+		result, _, err := callIntCounting(name, []*big.Int{n})
+		if err != nil {
+			return nil, err
+		}
+		return newFloat().SetInt(result), nil
+	case "sqrt":
+		if args[0].Sign() < 0 {
+			return nil, fmt.Errorf("square root of negative number: %s", args[0].Text('g', 10))
+		}
+		return newFloat().Sqrt(args[0]), nil
+	case "min", "max":
+		result := args[0]
+		for _, arg := range args[1:] {
+			if cmp := arg.Cmp(result); (name == "min" && cmp < 0) || (name == "max" && cmp > 0) {
+				result = arg
+			}
+		}
+		return newFloat().Set(result), nil
+	case "sum", "avg":
+		total := newFloat()
+		for _, arg := range args {
+			total.Add(total, arg)
+		}
+		if name == "avg" {
+			total.Quo(total, newFloat().SetInt64(int64(len(args))))
+		}
+		return total, nil
+	default:
+		return nil, fmt.Errorf("function %s is not supported in decimal mode", name)
+	}
+}
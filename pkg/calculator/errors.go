@@ -0,0 +1,37 @@
+package calculator
+
+import "errors"
+
+// Errors returned by evaluation, possibly wrapped with details such as the
+// unknown name; test for them with errors.Is
+var (
+	// ErrEmptyExpression reports an expression with nothing to evaluate
+	ErrEmptyExpression = errors.New("empty expression")
+	// ErrDivisionByZero reports division, floor division or modulo by zero
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrUnknownFunction reports a call to a function that is not defined
+	ErrUnknownFunction = errors.New("unknown function")
+	// ErrUnknownIdentifier reports a constant or variable that is not defined
+	ErrUnknownIdentifier = errors.New("unknown identifier")
+)
+
+// SyntaxError reports a malformed expression and where it went wrong
+type SyntaxError struct {
+	Position  int    // Byte offset of the offending token in the expression
+	Message   string // Description of the problem
+	Offending string // Source text of the offending token
+}
+
+// Error returns the description of the problem
+func (e *SyntaxError) Error() string {
+	return e.Message
+}
+
+// newSyntaxError creates a syntax error blaming a token
+func newSyntaxError(token Token, message string) *SyntaxError {
+	return &SyntaxError{
+		Position:  token.Pos,
+		Message:   message,
+		Offending: token.Value,
+	}
+}
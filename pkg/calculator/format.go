@@ -0,0 +1,97 @@
+package calculator
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FormatOptions configures FormatResult. The zero value formats results in
+// their shortest exact form without separators.
+type FormatOptions struct {
+	// ThousandsSeparator is inserted between groups of three integer digits,
+	// such as ","; empty for none
+	ThousandsSeparator string
+	// DecimalPlaces rounds to at most this many digits after the decimal
+	// point, with halves away from zero like round(), dropping trailing
+	// zeros; nil keeps as many digits as needed to
+	// represent the result exactly
+	DecimalPlaces *int
+	// ScientificThreshold switches to scientific notation for magnitudes at or
+	// above it; zero never does
+	ScientificThreshold float64
+}
+
+// FormatResult formats a result for display, for example 1234567.5 as
+// "1,234,567.5" with a "," separator. Integers never show a fractional part,
+// and NaN and infinities are written as "NaN", "+Inf" and "-Inf".
+func FormatResult(v float64, opts FormatOptions) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+
+	places := -1
+	if opts.DecimalPlaces != nil && *opts.DecimalPlaces >= 0 {
+		places = *opts.DecimalPlaces
+	}
+
+	if opts.ScientificThreshold > 0 && math.Abs(v) >= opts.ScientificThreshold {
+		mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(v, 'e', places, 64), "e")
+		return trimFractionZeros(mantissa) + "e" + exponent
+	}
+
+	if places >= 0 {
+		// Round halves away from zero on the decimal form, as round() does,
+		// rather than leaving FormatFloat to round the binary value half to
+		// even, which would show 2.5 as 2 and 1.005 as 1
+		v = roundToPlaces(v, places, roundHalfAway)
+	}
+	text := trimFractionZeros(strconv.FormatFloat(v, 'f', places, 64))
+	if text == "-0" {
+		// A small negative value rounded away entirely
+		text = "0"
+	}
+	if opts.ThousandsSeparator == "" {
+		return text
+	}
+
+	sign, digits := "", text
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(digits, ".")
+	text = sign + groupThousands(integer, opts.ThousandsSeparator)
+	if hasFraction {
+		text += "." + fraction
+	}
+	return text
+}
+
+// trimFractionZeros drops trailing zeros after a decimal point, and the point
+// itself when nothing is left after it
+func trimFractionZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// groupThousands inserts sep between groups of three digits, counted from the right
+func groupThousands(digits, sep string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,162 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"unicode"
+)
+
+// builtinFunctions maps function names to their implementations
+var builtinFunctions = map[string]func(float64) float64{
+	"sqrt": math.Sqrt,
+	"sin":  math.Sin,
+	"cos":  math.Cos,
+	"tan":  math.Tan,
+	"asin": math.Asin,
+	"acos": math.Acos,
+	"atan": math.Atan,
+	"log":  math.Log10,
+	"ln":   math.Log,
+}
+
+// variadic is the maximum argument count of functions without a limit
+const variadic = -1
+
+// multiArgFunction is a built-in function taking between minArgs and maxArgs
+// arguments, or any number of at least minArgs when maxArgs is variadic
+type multiArgFunction struct {
+	minArgs int
+	maxArgs int
+	fn      func(args []float64) (float64, error)
+}
+
+// multiArgFunctions maps names of functions with several, optional or a
+// variable number of arguments to their implementations
+var multiArgFunctions = map[string]multiArgFunction{
+	"nCr":   {minArgs: 2, maxArgs: 2, fn: combinations},
+	"nPr":   {minArgs: 2, maxArgs: 2, fn: permutations},
+	"min":   {minArgs: 1, maxArgs: variadic, fn: minimum},
+	"max":   {minArgs: 1, maxArgs: variadic, fn: maximum},
+	"sum":   {minArgs: 1, maxArgs: variadic, fn: sum},
+	"avg":   {minArgs: 1, maxArgs: variadic, fn: average},
+	"round": {minArgs: 1, maxArgs: 2, fn: roundingFunction(roundHalfAway)},
+	"floor": {minArgs: 1, maxArgs: 2, fn: roundingFunction(roundFloor)},
+	"ceil":  {minArgs: 1, maxArgs: 2, fn: roundingFunction(roundCeil)},
+	"trunc": {minArgs: 1, maxArgs: 2, fn: roundingFunction(roundTrunc)},
+}
+
+// builtinConstants maps constant names to their values
+var builtinConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// lookupConstant resolves a named constant
+func lookupConstant(name string) (float64, error) {
+	val, ok := builtinConstants[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownIdentifier, name)
+	}
+	return val, nil
+}
+
+// callFunction applies a built-in function, the factorial operator or a
+// conditional to its arguments
+func callFunction(name string, args []float64) (float64, error) {
+	minArgs, maxArgs, ok := functionArity(name)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownFunction, name)
+	}
+	if err := checkArgumentCount(name, minArgs, maxArgs, len(args)); err != nil {
+		return 0, err
+	}
+
+	if name == factorialOperator {
+		return factorial(args[0])
+	}
+	if name == conditionalOperator {
+		return conditional(args), nil
+	}
+	if fn, ok := builtinFunctions[name]; ok {
+		return fn(args[0]), nil
+	}
+	return multiArgFunctions[name].fn(args)
+}
+
+// checkArgumentCount checks that a function taking minArgs to maxArgs
+// arguments received count arguments
+func checkArgumentCount(name string, minArgs, maxArgs, count int) error {
+	switch {
+	case minArgs == maxArgs && count != minArgs:
+		return fmt.Errorf("function %s takes %d argument(s), got %d", name, minArgs, count)
+	case maxArgs == variadic && count < minArgs:
+		return fmt.Errorf("function %s takes at least %d argument(s), got %d", name, minArgs, count)
+	case maxArgs != variadic && (count < minArgs || count > maxArgs):
+		return fmt.Errorf("function %s takes %d to %d arguments, got %d", name, minArgs, maxArgs, count)
+	}
+	return nil
+}
+
+// functionArity returns the minimum and maximum number of arguments a
+// built-in function, the factorial operator or a conditional takes, and
+// whether the name is known. The maximum is variadic for functions without a
+// limit.
+func functionArity(name string) (minArgs, maxArgs int, ok bool) {
+	if name == factorialOperator {
+		return 1, 1, true
+	}
+	if name == conditionalOperator {
+		return 3, 3, true
+	}
+	if _, ok := builtinFunctions[name]; ok {
+		return 1, 1, true
+	}
+	if f, ok := multiArgFunctions[name]; ok {
+		return f.minArgs, f.maxArgs, true
+	}
+	return 0, 0, false
+}
+
+// isIdentifier checks if a name is tokenized as a single identifier: a
+// letter or underscore followed by letters, digits and underscores
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// minimum returns the smallest argument
+func minimum(args []float64) (float64, error) {
+	result := args[0]
+	for _, arg := range args[1:] {
+		result = math.Min(result, arg)
+	}
+	return result, nil
+}
+
+// maximum returns the largest argument
+func maximum(args []float64) (float64, error) {
+	result := args[0]
+	for _, arg := range args[1:] {
+		result = math.Max(result, arg)
+	}
+	return result, nil
+}
+
+// sum returns the sum of the arguments
+func sum(args []float64) (float64, error) {
+	total := 0.0
+	for _, arg := range args {
+		total += arg
+	}
+	return total, nil
+}
+
+// average returns the arithmetic mean of the arguments
+func average(args []float64) (float64, error) {
+	total, _ := sum(args)
+	return total / float64(len(args)), nil
+}
@@ -0,0 +1,165 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// EvaluateInt evaluates an expression with exact integer arithmetic. It supports
+// +, -, *, ^ with a non-negative exponent, floor division (//), modulo (%),
+// division (/) when the quotient is exact, and the bitwise operators.
+// Fractional literals and divisions that would produce a fraction are rejected.
+func EvaluateInt(expression string) (*big.Int, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, ErrEmptyExpression
+	}
+
+	tokens, err := scanTokens(expression, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("invalid expression")
+	}
+
+	return reduceTokens(tokens, intOperands)
+}
+
+// intOperands evaluates expressions with exact integer arithmetic
+var intOperands = operandOps[*big.Int]{
+	parse:    parseIntOperand,
+	apply:    applyIntOperator,
+	call:     callIntFunction,
+	constant: lookupIntConstant,
+	truthy: func(cond *big.Int) bool {
+		return cond.Sign() != 0
+	},
+}
+
+// callIntFunction computes factorial, nCr and nPr exactly, chooses the branch
+// of a conditional and rejects other function calls, whose results are not
+// exact integers
+func callIntFunction(name string, args []*big.Int) (*big.Int, error) {
+	if name == conditionalOperator {
+		if args[0].Sign() != 0 {
+			return args[1], nil
+		}
+		return args[2], nil
+	}
+	if result, ok, err := callIntCounting(name, args); ok {
+		return result, err
+	}
+	return nil, fmt.Errorf("function %s is not supported in integer mode", name)
+}
+
+// parseIntOperand parses a number token as an exact integer
+func parseIntOperand(token string) (*big.Int, error) {
+	if negative, digits, base, ok := radixLiteral(token); ok {
+		val, ok := new(big.Int).SetString(digits, base)
+		if !ok {
+			return nil, fmt.Errorf("malformed %s literal: %s", radixNames[base], token)
+		}
+		if negative {
+			val.Neg(val)
+		}
+		return val, nil
+	}
+
+	val, ok := new(big.Int).SetString(token, 10)
+	if !ok {
+		return nil, fmt.Errorf("non-integer literal: %s", token)
+	}
+	return val, nil
+}
+
+// maxIntShift bounds shift counts in integer mode to keep results a sensible size
+const maxIntShift = 1 << 16
+
+// maxIntPowBits bounds the bit length of powers in integer mode to keep
+// results a sensible size
+const maxIntPowBits = 1 << 20
+
+// applyIntOperator applies an operator to two integer operands
+func applyIntOperator(a, b *big.Int, operator string) (*big.Int, error) {
+	switch operator {
+	case "+":
+		return new(big.Int).Add(a, b), nil
+	case "-":
+		return new(big.Int).Sub(a, b), nil
+	case "*":
+		return new(big.Int).Mul(a, b), nil
+	case "/":
+		if b.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		quotient, remainder := new(big.Int).QuoRem(a, b, new(big.Int))
+		if remainder.Sign() != 0 {
+			return nil, fmt.Errorf("non-integer result: %s / %s", a, b)
+		}
+		return quotient, nil
+	case "//":
+		if b.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		// QuoRem truncates towards zero; step down when the signs differ
+		quotient, remainder := new(big.Int).QuoRem(a, b, new(big.Int))
+		if remainder.Sign() != 0 && remainder.Sign() != b.Sign() {
+			quotient.Sub(quotient, big.NewInt(1))
+		}
+		return quotient, nil
+	case "%":
+		if b.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		// Floored modulo: the result takes the sign of the divisor, matching //
+		remainder := new(big.Int).Rem(a, b)
+		if remainder.Sign() != 0 && remainder.Sign() != b.Sign() {
+			remainder.Add(remainder, b)
+		}
+		return remainder, nil
+	case "^":
+		if b.Sign() < 0 {
+			return nil, fmt.Errorf("negative exponent: %s", b)
+		}
+		// a^b has about a.BitLen() * b bits; 0, 1 and -1 stay small for any b
+		if a.CmpAbs(big.NewInt(1)) > 0 && (!b.IsInt64() || b.Int64() > maxIntPowBits/int64(a.BitLen())) {
+			return nil, fmt.Errorf("exponent too large: %s ^ %s", a, b)
+		}
+		return new(big.Int).Exp(a, b, nil), nil
+	case "&":
+		return new(big.Int).And(a, b), nil
+	case "|":
+		return new(big.Int).Or(a, b), nil
+	case xorOperator:
+		return new(big.Int).Xor(a, b), nil
+	case "<<", ">>":
+		if b.Sign() < 0 || !b.IsUint64() || b.Uint64() > maxIntShift {
+			return nil, fmt.Errorf("invalid shift count: %s", b)
+		}
+		if operator == "<<" {
+			return new(big.Int).Lsh(a, uint(b.Uint64())), nil
+		}
+		return new(big.Int).Rsh(a, uint(b.Uint64())), nil
+	case complementOperator:
+		return new(big.Int).Not(b), nil
+	case "==", "!=", "<", "<=", ">", ">=":
+		holds, err := comparisonHolds(a.Cmp(b), operator)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(boolToInt(holds)), nil
+	default:
+		return nil, fmt.Errorf("unknown operator: %s", operator)
+	}
+}
+
+// lookupIntConstant rejects named constants, none of which are integers
+func lookupIntConstant(name string) (*big.Int, error) {
+	if _, ok := builtinConstants[name]; ok {
+		return nil, fmt.Errorf("constant %s is not supported in integer mode", name)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownIdentifier, name)
+}
@@ -0,0 +1,105 @@
+package calculator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultProviderTimeout bounds a single call to a subprocess function provider
+const DefaultProviderTimeout = 5 * time.Second
+
+// SubprocessProvider supplies custom functions implemented by an external
+// helper program. Each call starts the helper, writes a request such as
+// {"func":"double","args":[21]} to its stdin and reads a response of the
+// form {"result":42} or {"error":"message"} from its stdout.
+type SubprocessProvider struct {
+	Command string
+	Args    []string
+	Env     []string      // Environment for the helper; nil inherits the current one
+	Timeout time.Duration // Per call; zero means DefaultProviderTimeout
+}
+
+// providerRequest is the JSON request sent to a provider helper
+type providerRequest struct {
+	Func string    `json:"func"`
+	Args []float64 `json:"args"`
+}
+
+// providerResponse is the JSON response read from a provider helper
+type providerResponse struct {
+	Result *float64 `json:"result"`
+	Error  string   `json:"error"`
+}
+
+// NewSubprocessProvider creates a provider running command with args
+func NewSubprocessProvider(command string, args ...string) *SubprocessProvider {
+	return &SubprocessProvider{
+		Command: command,
+		Args:    args,
+		Timeout: DefaultProviderTimeout,
+	}
+}
+
+// Call invokes the named function in the helper program
+func (p *SubprocessProvider) Call(name string, args ...float64) (float64, error) {
+	request, err := json.Marshal(providerRequest{Func: name, Args: args})
+	if err != nil {
+		return 0, fmt.Errorf("function %s: %w", name, err)
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = DefaultProviderTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.Env = p.Env
+	cmd.Stdin = bytes.NewReader(request)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return 0, fmt.Errorf("function %s: provider timed out after %v", name, timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return 0, fmt.Errorf("function %s: provider failed: %v: %s", name, err, msg)
+		}
+		return 0, fmt.Errorf("function %s: provider failed: %v", name, err)
+	}
+
+	var response providerResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return 0, fmt.Errorf("function %s: invalid provider response: %v", name, err)
+	}
+	if response.Error != "" {
+		return 0, fmt.Errorf("function %s: %s", name, response.Error)
+	}
+	if response.Result == nil {
+		return 0, fmt.Errorf("function %s: provider response has no result", name)
+	}
+	return *response.Result, nil
+}
+
+// RegisterProvider registers names on the calculator as functions served by
+// the provider, each taking arity arguments
+func (c *Calculator) RegisterProvider(p *SubprocessProvider, arity int, names ...string) error {
+	for _, name := range names {
+		call := func(args []float64) (float64, error) {
+			return p.Call(name, args...)
+		}
+		if err := c.register(name, arity, call); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// roundingMode selects how a value is rounded to an integer
+type roundingMode int
+
+const (
+	roundHalfAway roundingMode = iota // round, with halves away from zero
+	roundFloor                        // floor, towards negative infinity
+	roundCeil                         // ceil, towards positive infinity
+	roundTrunc                        // trunc, towards zero
+)
+
+// maxRoundingPlaces bounds the decimal places argument of the rounding functions
+const maxRoundingPlaces = 308
+
+// roundingFunction returns a function of one or two arguments that rounds its
+// first argument with mode, to the number of decimal places given by the
+// optional second argument
+func roundingFunction(mode roundingMode) func(args []float64) (float64, error) {
+	return func(args []float64) (float64, error) {
+		if len(args) == 1 {
+			return roundToInteger(args[0], mode), nil
+		}
+
+		places := args[1]
+		if places != math.Trunc(places) || math.Abs(places) > maxRoundingPlaces {
+			return 0, fmt.Errorf("decimal places must be an integer between %d and %d, got %v",
+				-maxRoundingPlaces, maxRoundingPlaces, places)
+		}
+		return roundToPlaces(args[0], int(places), mode), nil
+	}
+}
+
+// roundToInteger rounds a value to an integer with mode
+func roundToInteger(x float64, mode roundingMode) float64 {
+	var result float64
+	switch mode {
+	case roundFloor:
+		result = math.Floor(x)
+	case roundCeil:
+		result = math.Ceil(x)
+	case roundTrunc:
+		result = math.Trunc(x)
+	default:
+		result = math.Round(x)
+	}
+	// Avoid printing -0 for results like round(-0.4)
+	if result == 0 {
+		return 0
+	}
+	return result
+}
+
+// roundToPlaces rounds x to the given number of decimal places; negative
+// places round to tens, hundreds and so on. Rounding works on the shortest
+// decimal representation of x rather than its binary value, so round(1.005, 2)
+// is 1.01 and floor(0.29, 2) is 0.29, as written, although 1.005 is stored as
+// 1.00499... and 0.29 * 100 is 28.999... in float64.
+func roundToPlaces(x float64, places int, mode roundingMode) float64 {
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		return x
+	}
+
+	value, ok := new(big.Rat).SetString(strconv.FormatFloat(x, 'g', -1, 64))
+	if !ok {
+		return x
+	}
+
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(places))), nil))
+	if places < 0 {
+		scale.Inv(scale)
+	}
+	value.Mul(value, scale)
+
+	// Quotient truncated towards zero, with the remainder taking the sign of x
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	switch mode {
+	case roundFloor:
+		if remainder.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		}
+	case roundCeil:
+		if remainder.Sign() > 0 {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	case roundHalfAway:
+		twice := new(big.Int).Abs(remainder)
+		twice.Lsh(twice, 1)
+		if twice.Cmp(value.Denom()) >= 0 {
+			quotient.Add(quotient, big.NewInt(int64(remainder.Sign())))
+		}
+	}
+
+	result, _ := new(big.Rat).Quo(new(big.Rat).SetInt(quotient), scale).Float64()
+	return result
+}
+
+// abs returns the absolute value of an int
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,179 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ToRPN converts an infix expression to reverse Polish notation, returning the
+// postfix tokens in evaluation order. For example "2 + 3 * 4" becomes
+// ["2", "3", "4", "*", "+"].
+func ToRPN(expression string) ([]string, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, ErrEmptyExpression
+	}
+
+	tokens, err := scanTokens(expression, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, errors.New("invalid expression")
+	}
+
+	return reduceTokens(tokens, rpnOperands)
+}
+
+// rpnOperands builds postfix token sequences instead of values
+var rpnOperands = operandOps[[]string]{
+	parse:    parseRPNOperand,
+	apply:    applyRPNOperator,
+	call:     callRPNFunction,
+	constant: rpnConstant,
+}
+
+// parseRPNOperand turns a number token into a single-token postfix sequence
+func parseRPNOperand(token string) ([]string, error) {
+	if _, err := parseFloatOperand(token); err != nil {
+		return nil, err
+	}
+	return []string{token}, nil
+}
+
+// applyRPNOperator joins the postfix sequences of two operands and an operator.
+// The complement only takes its right operand, since the left one is the
+// implicit -1 added by reduceTokens.
+func applyRPNOperator(a, b []string, operator string) ([]string, error) {
+	if operator == complementOperator {
+		a = nil
+	}
+	result := make([]string, 0, len(a)+len(b)+1)
+	result = append(result, a...)
+	result = append(result, b...)
+	return append(result, operator), nil
+}
+
+// callRPNFunction appends a function name after its arguments' postfix
+// sequences. Functions without a fixed argument count are written with the
+// count, as in "1 2 3 max:3", since postfix has no parentheses to delimit them.
+func callRPNFunction(name string, args [][]string) ([]string, error) {
+	minArgs, maxArgs, ok := functionArity(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFunction, name)
+	}
+	if err := checkArgumentCount(name, minArgs, maxArgs, len(args)); err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, arg := range args {
+		result = append(result, arg...)
+	}
+	if minArgs != maxArgs {
+		return append(result, fmt.Sprintf("%s:%d", name, len(args))), nil
+	}
+	return append(result, name), nil
+}
+
+// rpnFunction resolves a postfix function token to its name and the number
+// of operands it takes, including the "name:count" form of functions without
+// a fixed argument count
+func rpnFunction(field string) (string, int, bool) {
+	name, count, hasCount := strings.Cut(field, ":")
+	minArgs, maxArgs, ok := functionArity(name)
+	if !ok || hasCount != (minArgs != maxArgs) {
+		return "", 0, false
+	}
+	if !hasCount {
+		return name, minArgs, true
+	}
+
+	n, err := strconv.Atoi(count)
+	if err != nil || checkArgumentCount(name, minArgs, maxArgs, n) != nil {
+		return "", 0, false
+	}
+	return name, n, true
+}
+
+// rpnConstant emits a named constant as a single postfix token
+func rpnConstant(name string) ([]string, error) {
+	if _, err := lookupConstant(name); err != nil {
+		return nil, err
+	}
+	return []string{name}, nil
+}
+
+// EvaluateRPN evaluates an expression given in reverse Polish notation as
+// whitespace-separated tokens, for example "2 3 4 * +"
+func EvaluateRPN(expression string) (float64, error) {
+	fields := strings.Fields(expression)
+	if len(fields) == 0 {
+		return 0, ErrEmptyExpression
+	}
+
+	values := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		if field == complementOperator {
+			if len(values) < 1 {
+				return 0, fmt.Errorf("stack underflow at operator %s", field)
+			}
+
+			result, err := applyBitwise(-1, values[len(values)-1], field)
+			if err != nil {
+				return 0, err
+			}
+			values[len(values)-1] = result
+			continue
+		}
+
+		// Functions come first since the conditional ? takes three operands
+		if name, arity, ok := rpnFunction(field); ok {
+			if len(values) < arity {
+				return 0, fmt.Errorf("stack underflow at function %s", field)
+			}
+
+			result, err := callFunction(name, values[len(values)-arity:])
+			if err != nil {
+				return 0, err
+			}
+			values = append(values[:len(values)-arity], result)
+			continue
+		}
+
+		if isOperatorString(field) {
+			if len(values) < 2 {
+				return 0, fmt.Errorf("stack underflow at operator %s", field)
+			}
+
+			a, b := values[len(values)-2], values[len(values)-1]
+			values = values[:len(values)-2]
+
+			result, err := applyOperator(a, b, field)
+			if err != nil {
+				return 0, err
+			}
+			values = append(values, result)
+			continue
+		}
+
+		if val, ok := builtinConstants[field]; ok {
+			values = append(values, val)
+			continue
+		}
+
+		val, err := parseFloatOperand(field)
+		if err != nil {
+			return 0, err
+		}
+		values = append(values, val)
+	}
+
+	if len(values) != 1 {
+		return 0, fmt.Errorf("%d operands left on the stack", len(values))
+	}
+
+	return values[0], nil
+}
@@ -0,0 +1,281 @@
+package calculator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TokenKind identifies the kind of a lexical token
+type TokenKind int
+
+const (
+	TokenNumber TokenKind = iota
+	TokenOperator
+	TokenLeftParen
+	TokenRightParen
+	TokenTrivia // Whitespace, only emitted when trivia is preserved
+	TokenIdent  // Function, constant or variable name
+	TokenAssign // "=" in a variable assignment
+	TokenComma  // Separates function arguments
+)
+
+// Token is a lexical token of an expression
+type Token struct {
+	Kind  TokenKind
+	Value string // Source text of the token
+	Pos   int    // Byte offset of the token in the expression
+}
+
+// TokenizeOptions configures Tokenize
+type TokenizeOptions struct {
+	// PreserveTrivia emits whitespace as TokenTrivia tokens so that
+	// concatenating the token values reproduces the input exactly
+	PreserveTrivia bool
+}
+
+// Tokenize splits an expression into tokens as seen by Evaluate
+func Tokenize(expression string) ([]Token, error) {
+	return scanTokens(expression, false)
+}
+
+// TokenizeWithOptions splits an expression into tokens using the given options
+func TokenizeWithOptions(expression string, opts TokenizeOptions) ([]Token, error) {
+	return scanTokens(expression, opts.PreserveTrivia)
+}
+
+// scanTokens splits an expression into tokens. Token values are slices of the
+// expression, so every token covers a contiguous byte range.
+func scanTokens(expression string, preserveTrivia bool) ([]Token, error) {
+	// Most expressions have at most one token per two bytes
+	tokens := make([]Token, 0, len(expression)/2+1)
+
+	// Keep track of whether the previous token was an operator or opening parenthesis
+	// This helps us identify negative numbers
+	previousTokenIsOperator := true
+
+	// Start offset of the number or identifier currently being read, or -1
+	wordStart := -1
+	wordKind := TokenNumber
+	// Start offset of the whitespace run currently being read, or -1
+	triviaStart := -1
+
+	flushWord := func(end int) {
+		if wordStart < 0 {
+			return
+		}
+		value := expression[wordStart:end]
+		kind := wordKind
+		if value == "-" || (kind == TokenIdent && value == xorOperator) {
+			kind = TokenOperator
+			previousTokenIsOperator = true
+		} else if kind == TokenNumber && value[0] == '-' && bindsTighterThanSign(expression[end:]) {
+			// The sign of a base or factorial operand is negation applied
+			// afterwards, so that -2^2 is -(2^2) and -3! is -(3!)
+			tokens = append(tokens, Token{Kind: TokenOperator, Value: "-", Pos: wordStart})
+			value = value[1:]
+			wordStart++
+		}
+		tokens = append(tokens, Token{Kind: kind, Value: value, Pos: wordStart})
+		wordStart = -1
+	}
+	startWord := func(i int, kind TokenKind) {
+		if wordStart >= 0 && wordKind != kind {
+			flushWord(i)
+		}
+		if wordStart < 0 {
+			wordStart = i
+			wordKind = kind
+		}
+	}
+	flushTrivia := func(end int) {
+		if triviaStart < 0 {
+			return
+		}
+		if preserveTrivia {
+			tokens = append(tokens, Token{Kind: TokenTrivia, Value: expression[triviaStart:end], Pos: triviaStart})
+		}
+		triviaStart = -1
+	}
+
+	var previousChar rune
+
+	for i, char := range expression {
+		lastChar := previousChar
+		previousChar = char
+
+		if unicode.IsSpace(char) {
+			// If we have a current token, add it to tokens
+			flushWord(i)
+			if triviaStart < 0 {
+				triviaStart = i
+			}
+			continue
+		}
+		flushTrivia(i)
+
+		// Handle operators and parentheses
+		if isOperator(char) || char == '(' || char == ')' {
+			// A sign directly after the exponent marker of a number belongs
+			// to the number, as in 2e-3
+			if (char == '-' || char == '+') && wordStart >= 0 && wordKind == TokenNumber &&
+				endsWithExponentMarker(expression[wordStart:i]) {
+				continue
+			}
+
+			// If we have a current token, add it to tokens
+			flushWord(i)
+
+			// A second slash directly after "/" turns it into floor division,
+			// and doubled angle brackets into shifts
+			if char == lastChar && (char == '/' || char == '<' || char == '>') &&
+				len(tokens) > 0 && tokens[len(tokens)-1].Value == string(char) {
+				last := &tokens[len(tokens)-1]
+				last.Value = expression[last.Pos : i+1]
+				continue
+			}
+
+			// Special handling for minus sign (could be negative number)
+			if char == '-' && previousTokenIsOperator {
+				// This is likely a negative number, don't add the minus sign yet
+				startWord(i, TokenNumber)
+			} else {
+				// Add the operator or parenthesis as a separate token
+				tokens = append(tokens, Token{Kind: punctuationKind(char), Value: expression[i : i+1], Pos: i})
+				previousTokenIsOperator = (char == '(' || isOperator(char))
+			}
+		} else if char == '!' {
+			// Factorial is postfix, so an operand has just ended
+			flushWord(i)
+			tokens = append(tokens, Token{Kind: TokenOperator, Value: expression[i : i+1], Pos: i})
+			previousTokenIsOperator = false
+		} else if char == ',' {
+			flushWord(i)
+			tokens = append(tokens, Token{Kind: TokenComma, Value: expression[i : i+1], Pos: i})
+			previousTokenIsOperator = true
+		} else if char == '=' {
+			flushWord(i)
+
+			// "=" directly after <, >, = or ! completes a comparison operator
+			if len(tokens) > 0 && tokens[len(tokens)-1].Pos == i-1 &&
+				strings.ContainsRune("<>=!", lastChar) && tokens[len(tokens)-1].Value == string(lastChar) {
+				last := &tokens[len(tokens)-1]
+				last.Kind = TokenOperator
+				last.Value = expression[last.Pos : i+1]
+				previousTokenIsOperator = true
+				continue
+			}
+			tokens = append(tokens, Token{Kind: TokenAssign, Value: expression[i : i+1], Pos: i})
+			previousTokenIsOperator = true
+		} else if unicode.IsLetter(char) && wordStart >= 0 && wordKind == TokenNumber &&
+			continuesRadixLiteral(expression[wordStart:i], char) {
+			// Letters after a 0x, 0b or 0o prefix belong to the integer literal
+			previousTokenIsOperator = false
+		} else if (char == 'e' || char == 'E') && wordStart >= 0 && wordKind == TokenNumber &&
+			startsExponent(expression[i+1:]) {
+			// An exponent marker followed by a digit or sign continues the
+			// number in scientific notation, as in 1e3 or 2e-3, rather than
+			// multiplying by the constant e
+			previousTokenIsOperator = false
+		} else if unicode.IsLetter(char) || char == '_' ||
+			(unicode.IsDigit(char) && wordStart >= 0 && wordKind == TokenIdent) {
+			// Identifiers start with a letter and may continue with digits
+			startWord(i, TokenIdent)
+			previousTokenIsOperator = false
+		} else if unicode.IsDigit(char) || char == '.' {
+			startWord(i, TokenNumber)
+			previousTokenIsOperator = false
+		} else {
+			return nil, &SyntaxError{
+				Position:  i,
+				Message:   fmt.Sprintf("invalid character: %c", char),
+				Offending: string(char),
+			}
+		}
+	}
+
+	// Add the last token if it exists
+	flushWord(len(expression))
+	flushTrivia(len(expression))
+
+	return tokens, nil
+}
+
+// bindsTighterThanSign checks if the text after a negative number starts
+// with a power or factorial, which apply before the number's sign. "!=" is a
+// comparison rather than a factorial.
+func bindsTighterThanSign(rest string) bool {
+	rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+	return strings.HasPrefix(rest, "^") || (strings.HasPrefix(rest, "!") && !strings.HasPrefix(rest, "!="))
+}
+
+// punctuationKind returns the token kind of an operator or parenthesis character
+func punctuationKind(char rune) TokenKind {
+	switch char {
+	case '(':
+		return TokenLeftParen
+	case ')':
+		return TokenRightParen
+	default:
+		return TokenOperator
+	}
+}
+
+// radixBases maps integer literal prefixes to their base
+var radixBases = map[string]int{
+	"0x": 16, "0X": 16,
+	"0b": 2, "0B": 2,
+	"0o": 8, "0O": 8,
+}
+
+// radixNames names each integer literal base for error messages
+var radixNames = map[int]string{
+	16: "hexadecimal",
+	2:  "binary",
+	8:  "octal",
+}
+
+// radixLiteral splits a number token with a 0x, 0b or 0o prefix into its
+// sign, digits and base. ok is false for plain decimal numbers.
+func radixLiteral(token string) (negative bool, digits string, base int, ok bool) {
+	unsigned := strings.TrimPrefix(token, "-")
+	if len(unsigned) < 2 {
+		return false, "", 0, false
+	}
+	base, ok = radixBases[unsigned[:2]]
+	if !ok {
+		return false, "", 0, false
+	}
+	return len(unsigned) < len(token), unsigned[2:], base, true
+}
+
+// startsExponent checks if the text after an e or E is an exponent: a
+// digit, optionally preceded by a sign
+func startsExponent(rest string) bool {
+	if rest != "" && (rest[0] == '-' || rest[0] == '+') {
+		rest = rest[1:]
+	}
+	return rest != "" && rest[0] >= '0' && rest[0] <= '9'
+}
+
+// endsWithExponentMarker checks if a number word read so far is a decimal
+// number ending in the e or E of scientific notation
+func endsWithExponentMarker(word string) bool {
+	if _, _, _, ok := radixLiteral(word); ok {
+		return false
+	}
+	last := word[len(word)-1]
+	return last == 'e' || last == 'E'
+}
+
+// continuesRadixLiteral checks if a letter extends the number word read so
+// far: either the prefix letter after a lone 0, or a digit of a prefixed
+// literal. Invalid digits are kept so the whole literal is reported.
+func continuesRadixLiteral(word string, char rune) bool {
+	if strings.TrimPrefix(word, "-") == "0" {
+		_, ok := radixBases["0"+string(char)]
+		return ok
+	}
+	_, _, _, ok := radixLiteral(word)
+	return ok
+}
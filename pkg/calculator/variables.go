@@ -0,0 +1,173 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// ansName is the identifier referring to the previous result
+const ansName = "ans"
+
+// Calculator evaluates expressions while keeping variables and the previous
+// result between calls
+type Calculator struct {
+	variables map[string]float64
+	functions map[string]registeredFunction
+	angleMode AngleMode
+	ans       float64 // Last successfully evaluated result
+	hasAns    bool
+}
+
+// Function is a custom one-argument function registered on a Calculator
+type Function func(arg float64) (float64, error)
+
+// registeredFunction is a custom function with the number of arguments it takes
+type registeredFunction struct {
+	arity int
+	call  func(args []float64) (float64, error)
+}
+
+// New creates a calculator with no variables defined
+func New() *Calculator {
+	return &Calculator{
+		variables: make(map[string]float64),
+		functions: make(map[string]registeredFunction),
+	}
+}
+
+// Clone returns an independent copy of the calculator with the same
+// variables, previous result, registered functions and angle mode. Changes
+// to either calculator afterwards do not affect the other, so a preloaded
+// calculator can be cloned for each request of a server.
+func (c *Calculator) Clone() *Calculator {
+	clone := *c
+	clone.variables = maps.Clone(c.variables)
+	clone.functions = maps.Clone(c.functions)
+	return &clone
+}
+
+// RegisterFunction makes fn callable as name(x) in expressions evaluated by
+// the calculator. The name must be an identifier; built-in functions,
+// constants, ans and operator keywords such as xor cannot be replaced.
+func (c *Calculator) RegisterFunction(name string, fn Function) error {
+	return c.register(name, 1, func(args []float64) (float64, error) {
+		return fn(args[0])
+	})
+}
+
+// register validates name and makes call callable with arity arguments
+func (c *Calculator) register(name string, arity int, call func(args []float64) (float64, error)) error {
+	if !isIdentifier(name) {
+		return fmt.Errorf("invalid function name: %q", name)
+	}
+	if isReservedName(name) {
+		return fmt.Errorf("cannot register reserved name: %s", name)
+	}
+	if arity < 1 {
+		return fmt.Errorf("function %s must take at least 1 argument, got arity %d", name, arity)
+	}
+	c.functions[name] = registeredFunction{arity: arity, call: call}
+	return nil
+}
+
+// Eval evaluates an expression, which may reference previously assigned
+// variables and the previous result as ans. An expression of the form
+// "name = expression" stores the result under name and returns it.
+func (c *Calculator) Eval(expression string) (float64, error) {
+	if strings.TrimSpace(expression) == "" {
+		return 0, ErrEmptyExpression
+	}
+
+	tokens, err := scanTokens(expression, false)
+	if err != nil {
+		return 0, err
+	}
+
+	// A lone identifier is looked up directly
+	if len(tokens) == 1 && tokens[0].Kind == TokenIdent {
+		result, err := c.lookup(tokens[0].Value)
+		if err != nil {
+			return 0, err
+		}
+		c.ans, c.hasAns = result, true
+		return result, nil
+	}
+
+	// An assignment starts with an identifier followed by "="
+	var name string
+	if len(tokens) >= 2 && tokens[0].Kind == TokenIdent && tokens[1].Kind == TokenAssign {
+		name = tokens[0].Value
+		if isReservedName(name) {
+			return 0, fmt.Errorf("cannot assign to reserved name: %s", name)
+		}
+		if len(tokens) == 2 {
+			return 0, newSyntaxError(tokens[1], "invalid expression")
+		}
+		tokens = tokens[2:]
+	}
+
+	result, err := reduceTokens(tokens, c.operands())
+	if err != nil {
+		return 0, err
+	}
+
+	if name != "" {
+		c.variables[name] = result
+	}
+	c.ans, c.hasAns = result, true
+	return result, nil
+}
+
+// Variable returns the value of a variable and whether it is defined
+func (c *Calculator) Variable(name string) (float64, bool) {
+	val, ok := c.variables[name]
+	return val, ok
+}
+
+// operands returns the float64 operand operations with identifiers resolved
+// against the calculator's variables before the built-in constants, and
+// registered functions called before the built-in ones
+func (c *Calculator) operands() operandOps[float64] {
+	ops := floatOperands
+	ops.call = func(name string, args []float64) (float64, error) {
+		if fn, ok := c.functions[name]; ok {
+			if len(args) != fn.arity {
+				return 0, fmt.Errorf("function %s takes %d argument(s), got %d", name, fn.arity, len(args))
+			}
+			return fn.call(args)
+		}
+		return callAngleFunction(name, args, c.angleMode)
+	}
+	ops.constant = c.lookup
+	return ops
+}
+
+// lookup resolves an identifier to the previous result, a variable or a
+// built-in constant, in that order
+func (c *Calculator) lookup(name string) (float64, error) {
+	if name == ansName {
+		if !c.hasAns {
+			return 0, errors.New("no previous result")
+		}
+		return c.ans, nil
+	}
+	if val, ok := c.variables[name]; ok {
+		return val, nil
+	}
+	return lookupConstant(name)
+}
+
+// isReservedName checks if a name belongs to ans, an operator keyword or a
+// built-in constant or function
+func isReservedName(name string) bool {
+	if name == ansName || name == xorOperator || name == negationOperator {
+		return true
+	}
+	if _, ok := builtinConstants[name]; ok {
+		return true
+	}
+	_, _, ok := functionArity(name)
+	return ok
+}
@@ -0,0 +1,63 @@
+// Package version holds the build information reported by acousticalc
+// --version. Release builds set it at link time, for example:
+//
+//	go build -ldflags "-X github.com/dmisiuk/acousticalc/pkg/version.Version=1.2.0 \
+//	  -X github.com/dmisiuk/acousticalc/pkg/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/dmisiuk/acousticalc/pkg/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package version
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// Build information, overridden with -ldflags "-X ..."
+var (
+	Version = "dev"     // Semantic version, without a leading v
+	Commit  = "none"    // Git commit the binary was built from
+	Date    = "unknown" // Build date in RFC 3339 format
+)
+
+// Info is the build information of the running binary
+type Info struct {
+	Version    string
+	Commit     string
+	Date       string // Build date, set only at link time
+	CommitDate string // Time of the commit as recorded by the Go toolchain
+}
+
+// Get returns the build information. Values not set at link time fall back
+// to what the Go toolchain recorded, so "go install ...@v1.2.0" and builds
+// from a git checkout still report their module version and commit. The
+// toolchain records when the commit was made rather than when the binary was
+// built, so that time is kept as CommitDate.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, Date: Date}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "dev" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Version = strings.TrimPrefix(build.Main.Version, "v")
+	}
+	for _, setting := range build.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && info.Commit == "none":
+			info.Commit = setting.Value
+		case setting.Key == "vcs.time":
+			info.CommitDate = setting.Value
+		}
+	}
+	return info
+}
+
+// String formats the build information as printed by --version. Without a
+// build date the commit date is shown instead, labelled as such.
+func (i Info) String() string {
+	if i.Date == "unknown" && i.CommitDate != "" {
+		return fmt.Sprintf("acousticalc %s (commit %s, committed %s)", i.Version, i.Commit, i.CommitDate)
+	}
+	return fmt.Sprintf("acousticalc %s (commit %s, built %s)", i.Version, i.Commit, i.Date)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 	"time"
+
+	"github.com/dmisiuk/acousticalc/pkg/calculator/calctest"
 )
 
 // TestComponentInteraction tests interaction between different components
@@ -235,7 +237,6 @@ func testErrorHandlingIntegration(t *testing.T) error {
 // testMathUtilitiesIntegration tests math utilities integration
 func testMathUtilitiesIntegration(t *testing.T) error {
 	mathUtils := NewMathUtilities()
-	dataProvider := NewTestDataProvider()
 
 	// Test floating point comparison
 	comparisonTests := []struct {
@@ -264,16 +265,19 @@ func testMathUtilitiesIntegration(t *testing.T) error {
 		return fmt.Errorf("DefaultEpsilon() = %v, expected 1e-9", defaultEpsilon)
 	}
 
-	// Test integration with data provider
-	validExprs := dataProvider.GetValidExpressions()
-	for _, expr := range validExprs[:2] {
-		// Simulate calculation with floating point precision
-		result1 := 0.1 + 0.2
-		result2 := 0.3
-
-		if !mathUtils.AlmostEqual(result1, result2, mathUtils.DefaultEpsilon()) {
-			return fmt.Errorf("floating point comparison failed for expression %s: %v != %v",
-				expr, result1, result2)
+	// Test the default epsilon against real results with floating point drift
+	driftTests := []struct {
+		expr     string
+		expected float64
+	}{
+		{"0.1 + 0.2", 0.3},
+		{"0.3 - 0.2", 0.1},
+		{"0.1 * 3", 0.3},
+	}
+
+	for _, test := range driftTests {
+		if !calctest.AssertEvaluates(t, test.expr, test.expected, mathUtils.DefaultEpsilon()) {
+			return fmt.Errorf("floating point comparison failed for expression %s", test.expr)
 		}
 	}
 
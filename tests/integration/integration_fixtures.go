@@ -2,9 +2,10 @@ package integration
 
 import (
 	"fmt"
-	"math"
 	"os"
 	"testing"
+
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
 )
 
 // MockCalculator simulates a calculator for integration testing
@@ -282,27 +283,10 @@ func NewMathUtilities() *MathUtilities {
 	return &MathUtilities{}
 }
 
-// AlmostEqual checks if two floating point numbers are approximately equal
+// AlmostEqual checks if two floating point numbers are approximately equal,
+// using epsilon as both the relative and the absolute tolerance
 func (m *MathUtilities) AlmostEqual(a, b, epsilon float64) bool {
-	// Handle the exact equality case (including both zero)
-	if a == b {
-		return true
-	}
-
-	// Calculate absolute difference
-	diff := math.Abs(a - b)
-
-	// For zero values, only use absolute comparison
-	if a == 0 || b == 0 {
-		return diff <= epsilon
-	}
-
-	// For non-zero values, use both absolute and relative comparison
-	// The relative difference is often more meaningful for larger numbers
-	relativeDiff := diff / math.Max(math.Abs(a), math.Abs(b))
-
-	// Return true if either absolute or relative difference is within epsilon
-	return diff <= epsilon || relativeDiff <= epsilon
+	return calculator.AlmostEqual(a, b, epsilon, epsilon)
 }
 
 // DefaultEpsilon returns the default epsilon for floating point comparison
@@ -150,35 +150,33 @@ func testMockStateIsolation(t *testing.T) error {
 	mock2.SetError("10 / 0", fmt.Errorf("custom error")) // Different error
 
 	// Test that mocks maintain separate states
-	result1, err1 := mock1.Evaluate("2 + 3")
-	result2, err2 := mock2.Evaluate("2 + 3")
-
-	if result1 != 5.0 {
-		return fmt.Errorf("mock1 returned unexpected result: got %v, want %v", result1, 5.0)
-	}
-
-	if result2 != 99.0 {
-		return fmt.Errorf("mock2 returned unexpected result: got %v, want %v", result2, 99.0)
-	}
-
-	if err1 != nil {
-		return fmt.Errorf("mock1 returned unexpected error: %v", err1)
-	}
-
-	if err2 != nil {
-		return fmt.Errorf("mock2 returned unexpected error: %v", err2)
+	stateTests := []struct {
+		name     string
+		mock     *MockCalculator
+		expr     string
+		expected float64
+		errMsg   string
+	}{
+		{"mock1", mock1, "2 + 3", 5.0, ""},
+		{"mock2", mock2, "2 + 3", 99.0, ""},
+		{"mock1", mock1, "10 / 0", 0, "division by zero"},
+		{"mock2", mock2, "10 / 0", 0, "custom error"},
 	}
 
-	// Test error states
-	_, err1 = mock1.Evaluate("10 / 0")
-	_, err2 = mock2.Evaluate("10 / 0")
-
-	if err1 == nil || err1.Error() != "division by zero" {
-		return fmt.Errorf("mock1 returned unexpected error: got %v, want division by zero", err1)
-	}
-
-	if err2 == nil || err2.Error() != "custom error" {
-		return fmt.Errorf("mock2 returned unexpected error: got %v, want custom error", err2)
+	for _, test := range stateTests {
+		result, err := test.mock.Evaluate(test.expr)
+		if test.errMsg != "" {
+			if err == nil || err.Error() != test.errMsg {
+				return fmt.Errorf("%s returned unexpected error for %s: got %v, want %s", test.name, test.expr, err, test.errMsg)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s returned unexpected error for %s: %v", test.name, test.expr, err)
+		}
+		if result != test.expected {
+			return fmt.Errorf("%s returned unexpected result for %s: got %v, want %v", test.name, test.expr, result, test.expected)
+		}
 	}
 
 	t.Logf("Mock state isolation test completed successfully")
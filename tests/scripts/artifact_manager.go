@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"time"
@@ -116,7 +117,7 @@ func (am *ArtifactManager) classifyArtifact(path string, info os.FileInfo) *Arti
 
 // parseScreenshotMetadata extracts metadata from screenshot filenames
 func (am *ArtifactManager) parseScreenshotMetadata(artifact *ArtifactInfo, fileName, path string) {
-	// Format: testname_eventtype_timestamp.png
+	// Format: testname_eventtype_sequence.png
 	parts := strings.Split(fileName, "_")
 
 	if len(parts) >= 3 {
@@ -127,11 +128,8 @@ func (am *ArtifactManager) parseScreenshotMetadata(artifact *ArtifactInfo, fileN
 		// Extract event type (second to last part)
 		artifact.EventType = parts[len(parts)-2]
 
-		// Extract timestamp (last part)
-		timestampStr := parts[len(parts)-1]
-		if timestamp, err := time.Parse("20060102_150405", timestampStr); err == nil {
-			artifact.Timestamp = timestamp
-		}
+		// Extract capture sequence within the test run (last part)
+		artifact.Metadata["sequence"] = parts[len(parts)-1]
 	}
 
 	artifact.Metadata["format"] = "PNG"
@@ -251,6 +249,55 @@ func (am *ArtifactManager) ExportMetadata(outputPath string) error {
 	return os.WriteFile(outputPath, jsonData, 0644)
 }
 
+// BaselineDir returns the visual baseline directory for the current platform
+func (am *ArtifactManager) BaselineDir() string {
+	return filepath.Join(am.BaseDir, "baselines", runtime.GOOS)
+}
+
+// UpdateBaseline stores a captured image as the baseline for the current platform.
+// An existing baseline is only replaced when confirm is true.
+func (am *ArtifactManager) UpdateBaseline(capturedPath string, confirm bool) (string, error) {
+	baselinePath := filepath.Join(am.BaselineDir(), filepath.Base(capturedPath))
+
+	if _, err := os.Stat(baselinePath); err == nil && !confirm {
+		return baselinePath, fmt.Errorf("baseline %s already exists, use --confirm to overwrite", baselinePath)
+	}
+
+	data, err := os.ReadFile(capturedPath)
+	if err != nil {
+		return baselinePath, fmt.Errorf("failed to read captured image: %w", err)
+	}
+
+	if err := os.MkdirAll(am.BaselineDir(), 0755); err != nil {
+		return baselinePath, fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+
+	if err := os.WriteFile(baselinePath, data, 0644); err != nil {
+		return baselinePath, fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	return baselinePath, nil
+}
+
+// UpdateBaselines promotes all captured screenshots to baselines for the current platform
+func (am *ArtifactManager) UpdateBaselines(confirm bool) ([]string, error) {
+	updated := make([]string, 0)
+
+	for _, artifact := range am.Artifacts {
+		if artifact.Type != "screenshot" || strings.HasPrefix(artifact.Path, "baselines") {
+			continue
+		}
+
+		baselinePath, err := am.UpdateBaseline(filepath.Join(am.BaseDir, artifact.Path), confirm)
+		if err != nil {
+			return updated, err
+		}
+		updated = append(updated, baselinePath)
+	}
+
+	return updated, nil
+}
+
 // formatBytes formats byte count in human readable format
 func formatBytes(bytes int64) string {
 	const unit = 1024
@@ -401,6 +448,35 @@ func main() {
 
 		fmt.Printf("Metadata exported to: %s\n", outputPath)
 
+	case "baselines":
+		if len(os.Args) < 3 || os.Args[2] != "update" {
+			showHelp()
+			os.Exit(1)
+		}
+
+		confirm := false
+		for _, arg := range os.Args[3:] {
+			if arg == "--confirm" {
+				confirm = true
+			}
+		}
+
+		if err := manager.ScanArtifacts(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning artifacts: %v\n", err)
+			os.Exit(1)
+		}
+
+		updated, err := manager.UpdateBaselines(confirm)
+		for _, path := range updated {
+			fmt.Printf("  %s\n", path)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error updating baselines: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Updated %d baselines in: %s\n", len(updated), manager.BaselineDir())
+
 	default:
 		showHelp()
 		os.Exit(1)
@@ -425,6 +501,8 @@ Commands:
   summary                        Show artifact summary
   cleanup [--days N] [--execute]  Clean up old artifacts (default: dry-run)
   export [filename]              Export metadata to JSON
+  baselines update [--confirm]   Store captured screenshots as platform baselines
+                                 (existing baselines need --confirm)
 
 Environment Variables:
   ARTIFACT_DIR                   Base artifact directory (default: tests/artifacts)
@@ -435,6 +513,7 @@ Examples:
   %s summary
   %s cleanup --days 7 --execute
   %s export artifacts.json
+  %s baselines update --confirm
 
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestUpdateBaseline tests promoting a captured image to a platform baseline
+func TestUpdateBaseline(t *testing.T) {
+	baseDir := t.TempDir()
+	manager := NewArtifactManager(baseDir)
+
+	capturedDir := filepath.Join(baseDir, "screenshots", "unit")
+	if err := os.MkdirAll(capturedDir, 0755); err != nil {
+		t.Fatalf("Failed to create screenshot directory: %v", err)
+	}
+	captured := filepath.Join(capturedDir, "calculator_start_0.png")
+	original := []byte("first-render")
+	if err := os.WriteFile(captured, original, 0644); err != nil {
+		t.Fatalf("Failed to write captured image: %v", err)
+	}
+
+	t.Run("writes_platform_baseline", func(t *testing.T) {
+		baselinePath, err := manager.UpdateBaseline(captured, false)
+		if err != nil {
+			t.Fatalf("UpdateBaseline failed: %v", err)
+		}
+
+		expected := filepath.Join(baseDir, "baselines", runtime.GOOS, "calculator_start_0.png")
+		if baselinePath != expected {
+			t.Errorf("Expected baseline path %s, got %s", expected, baselinePath)
+		}
+
+		data, err := os.ReadFile(expected)
+		if err != nil {
+			t.Fatalf("Baseline was not written: %v", err)
+		}
+		if !bytes.Equal(data, original) {
+			t.Errorf("Baseline content mismatch: got %q", data)
+		}
+	})
+
+	// A new render replaces the capture on disk
+	if err := os.WriteFile(captured, []byte("second-render"), 0644); err != nil {
+		t.Fatalf("Failed to rewrite captured image: %v", err)
+	}
+
+	t.Run("refuses_overwrite_without_confirm", func(t *testing.T) {
+		if _, err := manager.UpdateBaseline(captured, false); err == nil {
+			t.Fatal("Expected error when overwriting baseline without confirmation")
+		}
+
+		data, _ := os.ReadFile(filepath.Join(manager.BaselineDir(), "calculator_start_0.png"))
+		if !bytes.Equal(data, original) {
+			t.Errorf("Baseline was modified without confirmation: got %q", data)
+		}
+	})
+
+	t.Run("overwrites_with_confirm", func(t *testing.T) {
+		baselinePath, err := manager.UpdateBaseline(captured, true)
+		if err != nil {
+			t.Fatalf("UpdateBaseline with confirm failed: %v", err)
+		}
+
+		data, _ := os.ReadFile(baselinePath)
+		if string(data) != "second-render" {
+			t.Errorf("Expected baseline to be replaced, got %q", data)
+		}
+	})
+
+	t.Run("update_all_skips_existing_baselines", func(t *testing.T) {
+		if err := manager.ScanArtifacts(); err != nil {
+			t.Fatalf("ScanArtifacts failed: %v", err)
+		}
+
+		updated, err := manager.UpdateBaselines(true)
+		if err != nil {
+			t.Fatalf("UpdateBaselines failed: %v", err)
+		}
+		if len(updated) != 1 {
+			t.Errorf("Expected 1 baseline update, got %d: %v", len(updated), updated)
+		}
+	})
+}
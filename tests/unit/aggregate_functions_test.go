@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestAggregateFunctions tests the variadic min, max, sum and avg functions
+func TestAggregateFunctions(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"max(3, 7, 2)", 7},
+		{"min(3, 7, 2)", 2},
+		{"avg(2, 4, 6)", 4},
+		{"sum(1, 2, 3)", 6},
+
+		// Single argument
+		{"max(5)", 5},
+		{"min(-5)", -5},
+		{"avg(9)", 9},
+		{"sum(4)", 4},
+
+		// Many arguments
+		{"sum(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)", 55},
+		{"max(1, 9, 2, 8, 3, 7, 4, 6, 5)", 9},
+		{"min(-1, -9, 2, 8, -3, 7)", -9},
+		{"avg(1, 2, 3, 4)", 2.5},
+
+		// Expressions as arguments and nesting
+		{"max(2 * 3, 10 / 2, 1 + 1)", 6},
+		{"sum(min(1, 2), max(3, 4))", 5},
+		{"avg(sqrt(16), 2 ^ 3, nCr(4, 2))", 6},
+		{"1 + max(1, 2) * 3", 7},
+		{"max(-1,-2)", -1},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.Evaluate(tc.expression)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", tc.expression, tc.expected, result)
+		}
+	}
+}
+
+// TestAggregateFunctionErrors tests that aggregate functions need at least one argument
+func TestAggregateFunctionErrors(t *testing.T) {
+	testCases := []struct {
+		expression string
+		message    string
+	}{
+		{"max()", "missing argument for function max"},
+		{"sum()", "missing argument for function sum"},
+		{"avg(1, )", "invalid expression"},
+		{"min(1 2)", "invalid expression"},
+	}
+
+	for _, tc := range testCases {
+		_, err := calculator.Evaluate(tc.expression)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s'", tc.expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("For expression '%s': expected error %q, got %q", tc.expression, tc.message, err.Error())
+		}
+	}
+}
+
+// TestAggregateFunctionsRPN tests that postfix output records the argument count
+func TestAggregateFunctionsRPN(t *testing.T) {
+	rpn, err := calculator.ToRPN("max(1, 2 + 3, 4) - sum(6)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "1 2 3 + 4 max:3 6 sum:1 -"
+	if strings.Join(rpn, " ") != expected {
+		t.Errorf("Expected %q, got %q", expected, strings.Join(rpn, " "))
+	}
+
+	result, err := calculator.EvaluateRPN(expected)
+	if err != nil || result != -1 {
+		t.Errorf("Expected -1, got %v (error: %v)", result, err)
+	}
+
+	if _, err := calculator.EvaluateRPN("1 2 max:3"); err == nil || err.Error() != "stack underflow at function max:3" {
+		t.Errorf("Expected underflow error, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"testing"
+)
+
+// TestEvaluateAllocations guards against allocation regressions in the evaluation hot path
+func TestEvaluateAllocations(t *testing.T) {
+	tests := []struct {
+		expression string
+		budget     float64
+	}{
+		{"42", 0},
+		{"pi", 0},
+		{"2 + 3", 1},
+		{"2 + 3 * 4 - 5 / 2", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				if _, err := calculator.Evaluate(tt.expression); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			})
+			if allocs > tt.budget {
+				t.Errorf("Evaluate(%q) allocated %v times per run, budget is %v", tt.expression, allocs, tt.budget)
+			}
+		})
+	}
+}
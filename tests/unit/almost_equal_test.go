@@ -0,0 +1,44 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"math"
+	"testing"
+)
+
+// TestAlmostEqual tests the relative/absolute tolerance comparison helper
+func TestAlmostEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     float64
+		relTol   float64
+		absTol   float64
+		expected bool
+	}{
+		{"Exact match", 1.5, 1.5, 0, 0, true},
+		{"Floating point noise", 0.1 + 0.2, 0.3, 1e-9, 0, true},
+		{"Near equal within relative tolerance", 1.0, 1.0 + 5e-10, 1e-9, 0, true},
+		{"Far apart", 1.0, 1.0001, 1e-9, 1e-9, false},
+		{"Opposite signs", -1.0, 1.0, 1e-9, 1e-9, false},
+		{"Large magnitudes need relative tolerance", 1e20, 1e20 + 1e5, 1e-9, 1e-9, true},
+		{"Absolute tolerance alone fails at large magnitude", 1e20, 1e20 + 1e5, 0, 1e-9, false},
+		{"Small magnitudes distinguished by relative tolerance", 1e-12, 2e-12, 1e-9, 0, false},
+		{"Absolute tolerance alone conflates small values", 1e-12, 2e-12, 0, 1e-9, true},
+		{"Relative tolerance never matches zero", 1e-15, 0, 1e-9, 0, false},
+		{"Absolute tolerance handles zero", 1e-15, 0, 1e-9, 1e-12, true},
+		{"Same infinity", math.Inf(1), math.Inf(1), 1e-9, 1e-9, true},
+		{"Different infinities", math.Inf(1), math.Inf(-1), 1e-9, 1e-9, false},
+		{"Infinity and finite", math.Inf(1), math.MaxFloat64, 1, 1, false},
+		{"NaN", math.NaN(), math.NaN(), 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := calculator.AlmostEqual(tt.a, tt.b, tt.relTol, tt.absTol)
+			if result != tt.expected {
+				t.Errorf("AlmostEqual(%v, %v, %v, %v) = %v, expected %v",
+					tt.a, tt.b, tt.relTol, tt.absTol, result, tt.expected)
+			}
+		})
+	}
+}
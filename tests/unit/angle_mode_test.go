@@ -0,0 +1,130 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"math"
+	"testing"
+)
+
+// TestAngleMode tests trigonometric functions in radians and degrees
+func TestAngleMode(t *testing.T) {
+	calc := calculator.New()
+	if calc.AngleMode() != calculator.Radians {
+		t.Fatalf("Expected default angle mode radians, got %v", calc.AngleMode())
+	}
+
+	radianCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"sin(pi/2)", 1},
+		{"cos(0)", 1},
+		{"tan(pi/4)", 1},
+		{"asin(1)", math.Pi / 2},
+	}
+	for _, tc := range radianCases {
+		assertCalcResult(t, calc, tc.expression, tc.expected)
+	}
+
+	calc.SetAngleMode(calculator.Degrees)
+	if calc.AngleMode() != calculator.Degrees {
+		t.Fatalf("Expected angle mode degrees, got %v", calc.AngleMode())
+	}
+
+	degreeCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"sin(0)", 0},
+		{"sin(30)", 0.5},
+		{"sin(45)", math.Sqrt2 / 2},
+		{"sin(90)", 1},
+		{"cos(0)", 1},
+		{"cos(30)", math.Sqrt(3) / 2},
+		{"cos(45)", math.Sqrt2 / 2},
+		{"cos(90)", 0},
+		{"tan(0)", 0},
+		{"tan(30)", 1 / math.Sqrt(3)},
+		{"tan(45)", 1},
+		{"asin(0.5)", 30},
+		{"acos(0.5)", 60},
+		{"atan(1)", 45},
+		{"asin(sin(90))", 90},
+		{"sqrt(16)", 4},
+	}
+	for _, tc := range degreeCases {
+		assertCalcResult(t, calc, tc.expression, tc.expected)
+	}
+
+	// Package-level evaluation always uses radians
+	result, err := calculator.Evaluate("sin(90)")
+	if err != nil || math.Abs(result-math.Sin(90)) > 1e-12 {
+		t.Errorf("Expected Evaluate to use radians, got %v (error: %v)", result, err)
+	}
+}
+
+// TestDegreeModeExactValues tests that multiples of 30 and 45 degrees give
+// exact results rather than values off by rounding the conversion to radians
+func TestDegreeModeExactValues(t *testing.T) {
+	calc := calculator.New()
+	calc.SetAngleMode(calculator.Degrees)
+
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"sin(30)", 0.5},
+		{"sin(150)", 0.5},
+		{"sin(180)", 0},
+		{"sin(210)", -0.5},
+		{"sin(270)", -1},
+		{"sin(360)", 0},
+		{"sin(-30)", -0.5},
+		{"sin(390)", 0.5},
+		{"sin(3600030)", 0.5},
+		{"sin(135)", math.Sqrt2 / 2},
+		{"cos(60)", 0.5},
+		{"cos(90)", 0},
+		{"cos(120)", -0.5},
+		{"cos(180)", -1},
+		{"cos(-60)", 0.5},
+		{"cos(270)", 0},
+		{"tan(45)", 1},
+		{"tan(135)", -1},
+		{"tan(180)", 0},
+		{"tan(-45)", -1},
+	}
+	for _, tc := range testCases {
+		result, err := calc.Eval(tc.expression)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected || math.Signbit(result) != math.Signbit(tc.expected) {
+			t.Errorf("For expression '%s': expected exactly %v, got %v", tc.expression, tc.expected, result)
+		}
+	}
+
+	// Other angles are still computed
+	assertCalcResult(t, calc, "sin(10)", math.Sin(10*math.Pi/180))
+	assertCalcResult(t, calc, "cos(200)", math.Cos(200*math.Pi/180))
+
+	for _, expression := range []string{"tan(90)", "tan(-270)"} {
+		if _, err := calc.Eval(expression); err == nil {
+			t.Errorf("Expected error for expression '%s'", expression)
+		}
+	}
+}
+
+// assertCalcResult checks a calculator result within a small tolerance
+func assertCalcResult(t *testing.T, calc *calculator.Calculator, expression string, expected float64) {
+	t.Helper()
+	result, err := calc.Eval(expression)
+	if err != nil {
+		t.Errorf("Unexpected error for expression '%s': %v", expression, err)
+		return
+	}
+	if math.Abs(result-expected) > 1e-9 {
+		t.Errorf("For expression '%s' in %v mode: expected %v, got %v", expression, calc.AngleMode(), expected, result)
+	}
+}
@@ -0,0 +1,176 @@
+package unit
+
+import (
+	"errors"
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"testing"
+)
+
+// parseExpression tokenizes and parses an expression
+func parseExpression(t *testing.T, expression string) calculator.Node {
+	t.Helper()
+	tokens, err := calculator.Tokenize(expression)
+	if err != nil {
+		t.Fatalf("Tokenize(%q) failed: %v", expression, err)
+	}
+	node, err := calculator.Parse(tokens)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %v", expression, err)
+	}
+	return node
+}
+
+// TestParseShape tests the syntax tree built for operator precedence
+func TestParseShape(t *testing.T) {
+	node := parseExpression(t, "2 + 3 * 4")
+
+	add, ok := node.(*calculator.BinaryNode)
+	if !ok || add.Op != "+" {
+		t.Fatalf("Expected an addition at the root, got %#v", node)
+	}
+	if left, ok := add.Left.(*calculator.NumberNode); !ok || left.Value != 2 {
+		t.Errorf("Expected left child 2, got %#v", add.Left)
+	}
+
+	mul, ok := add.Right.(*calculator.BinaryNode)
+	if !ok || mul.Op != "*" {
+		t.Fatalf("Expected a multiplication as right child, got %#v", add.Right)
+	}
+	if left, ok := mul.Left.(*calculator.NumberNode); !ok || left.Value != 3 {
+		t.Errorf("Expected 3 * 4, got %#v", mul)
+	}
+	if right, ok := mul.Right.(*calculator.NumberNode); !ok || right.Value != 4 {
+		t.Errorf("Expected 3 * 4, got %#v", mul)
+	}
+}
+
+// TestParseGroupsAndCalls tests groups, calls, identifiers and unary operators
+func TestParseGroupsAndCalls(t *testing.T) {
+	node := parseExpression(t, "(2 + 3) * max(pi, 4!)")
+
+	mul, ok := node.(*calculator.BinaryNode)
+	if !ok || mul.Op != "*" {
+		t.Fatalf("Expected a multiplication at the root, got %#v", node)
+	}
+	group, ok := mul.Left.(*calculator.GroupNode)
+	if !ok {
+		t.Fatalf("Expected a group as left child, got %#v", mul.Left)
+	}
+	if inner, ok := group.Inner.(*calculator.BinaryNode); !ok || inner.Op != "+" {
+		t.Errorf("Expected an addition inside the group, got %#v", group.Inner)
+	}
+
+	call, ok := mul.Right.(*calculator.CallNode)
+	if !ok || call.Name != "max" || len(call.Args) != 2 {
+		t.Fatalf("Expected max with two arguments, got %#v", mul.Right)
+	}
+	if ident, ok := call.Args[0].(*calculator.IdentNode); !ok || ident.Name != "pi" {
+		t.Errorf("Expected identifier pi, got %#v", call.Args[0])
+	}
+	if fact, ok := call.Args[1].(*calculator.UnaryNode); !ok || fact.Op != "!" {
+		t.Errorf("Expected factorial, got %#v", call.Args[1])
+	}
+}
+
+// TestParseString tests rendering syntax trees back to expression text
+func TestParseString(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   string
+	}{
+		{"2+3*4", "2 + 3 * 4"},
+		{"(2+3)*4", "(2 + 3) * 4"},
+		{"sqrt( 16 )", "sqrt(16)"},
+		{"nCr(5,2)", "nCr(5, 2)"},
+		{"~0xFF", "~0xFF"},
+		{"3!", "3!"},
+		{"-2.5 ^ 2", "-2.5 ^ 2"},
+		{"-pi", "-pi"},
+		{"2*-(1+1)", "2 * -(1 + 1)"},
+		{"unknown(x)", "unknown(x)"},
+	}
+
+	for _, tc := range testCases {
+		if actual := parseExpression(t, tc.expression).String(); actual != tc.expected {
+			t.Errorf("For expression '%s': expected %q, got %q", tc.expression, tc.expected, actual)
+		}
+	}
+}
+
+// TestEvalMatchesEvaluate tests that walking the syntax tree gives Evaluate's result
+func TestEvalMatchesEvaluate(t *testing.T) {
+	expressions := []string{
+		"2 + 3 * 4",
+		"(2 + 3) * 4",
+		"2 ^ 3 ^ 2",
+		"10 - 4 - 3",
+		"200 + 10%",
+		"17 % 5",
+		"7 // 2",
+		"sqrt(16) + max(1, 2, 3)",
+		"round(3.14159, 2)",
+		"5! / nCr(5, 2)",
+		"~6 & 3 | 8",
+		"2 > 1 ? 10 : 20",
+		"0 ? 1 / 0 : 5",
+		"pi * 2",
+		"-pi ^ 2 * 3",
+		"2 ^ -sqrt(4)",
+		"1 / 0",
+		"unknown + 1",
+		"nope(1)",
+	}
+
+	for _, expression := range expressions {
+		expected, expectedErr := calculator.Evaluate(expression)
+		tokens, err := calculator.Tokenize(expression)
+		if err != nil {
+			t.Fatalf("Tokenize(%q) failed: %v", expression, err)
+		}
+		node, err := calculator.Parse(tokens)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", expression, err)
+		}
+
+		actual, actualErr := calculator.Eval(node)
+		if (expectedErr == nil) != (actualErr == nil) {
+			t.Errorf("For expression '%s': Evaluate error %v, Eval error %v", expression, expectedErr, actualErr)
+			continue
+		}
+		if expectedErr != nil && expectedErr.Error() != actualErr.Error() {
+			t.Errorf("For expression '%s': Evaluate error %q, Eval error %q", expression, expectedErr, actualErr)
+		}
+		if actual != expected {
+			t.Errorf("For expression '%s': Evaluate gave %v, Eval gave %v", expression, expected, actual)
+		}
+	}
+}
+
+// TestParseErrors tests that malformed token sequences are syntax errors
+func TestParseErrors(t *testing.T) {
+	for _, expression := range []string{"2 +", "(2 + 3", "2 + 3)", "nCr(5,)", "max()"} {
+		tokens, err := calculator.Tokenize(expression)
+		if err != nil {
+			t.Fatalf("Tokenize(%q) failed: %v", expression, err)
+		}
+		_, err = calculator.Parse(tokens)
+		var syntaxErr *calculator.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("For expression '%s': expected *SyntaxError, got %v", expression, err)
+		}
+	}
+
+	if _, err := calculator.Parse(nil); err == nil {
+		t.Error("Expected error parsing no tokens")
+	}
+
+	// Trivia from TokenizeWithOptions is ignored
+	tokens, err := calculator.TokenizeWithOptions(" 1 +  2 ", calculator.TokenizeOptions{PreserveTrivia: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	node, err := calculator.Parse(tokens)
+	if err != nil || node.String() != "1 + 2" {
+		t.Errorf("Expected trivia to be skipped, got %v (error: %v)", node, err)
+	}
+}
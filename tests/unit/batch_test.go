@@ -0,0 +1,59 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"testing"
+)
+
+// TestEvaluateBatch tests that each batch entry is evaluated independently
+func TestEvaluateBatch(t *testing.T) {
+	exprs := []string{"2 + 3", "2 +", "10 / 4", "1 / 0", "sqrt(16)"}
+
+	results, err := calculator.EvaluateBatch(exprs)
+	if err == nil || err.Error() != "2 of 5 expressions failed" {
+		t.Errorf("Expected summary error, got %v", err)
+	}
+	if len(results) != len(exprs) {
+		t.Fatalf("Expected %d results, got %d", len(exprs), len(results))
+	}
+
+	expected := []struct {
+		result float64
+		failed bool
+	}{
+		{5, false},
+		{0, true},
+		{2.5, false},
+		{0, true},
+		{4, false},
+	}
+
+	for i, want := range expected {
+		got := results[i]
+		if got.Input != exprs[i] {
+			t.Errorf("Entry %d: expected input %q, got %q", i, exprs[i], got.Input)
+		}
+		if (got.Err != nil) != want.failed {
+			t.Errorf("Entry %d (%q): expected failed=%v, got error %v", i, got.Input, want.failed, got.Err)
+		}
+		if got.Result != want.result {
+			t.Errorf("Entry %d (%q): expected %v, got %v", i, got.Input, want.result, got.Result)
+		}
+	}
+}
+
+// TestEvaluateBatchAllValid tests that a batch without failures returns no error
+func TestEvaluateBatchAllValid(t *testing.T) {
+	results, err := calculator.EvaluateBatch([]string{"1", "2 * 3"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if results[0].Result != 1 || results[1].Result != 6 {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+
+	empty, err := calculator.EvaluateBatch(nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Expected empty batch to succeed, got %v and %v", empty, err)
+	}
+}
@@ -172,3 +172,74 @@ func BenchmarkCalculatorPerformance(b *testing.B) {
 		}
 	})
 }
+
+// representativeExpressions covers the expression shapes used in the allocation benchmarks
+var representativeExpressions = []struct {
+	name string
+	expr string
+}{
+	{"Simple", "2 + 3"},
+	{"Precedence", "2 + 3 * 4"},
+	{"Nested", "((2 + 3) * 4) - 5"},
+	{"Decimals", "3.14159 * 2.71828 + 1.41421 - 0.57721"},
+	{"Negatives", "-3 * (2 + -4) - -5"},
+}
+
+// BenchmarkEvaluateAllocs benchmarks Evaluate with allocation reporting
+func BenchmarkEvaluateAllocs(b *testing.B) {
+	for _, expr := range representativeExpressions {
+		b.Run(expr.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := calculator.Evaluate(expr.expr); err != nil {
+					b.Fatalf("Benchmark failed for %s: %v", expr.name, err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkTokenize benchmarks tokenization with allocation reporting
+func BenchmarkTokenize(b *testing.B) {
+	for _, expr := range representativeExpressions {
+		b.Run(expr.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := calculator.Tokenize(expr.expr); err != nil {
+					b.Fatalf("Benchmark failed for %s: %v", expr.name, err)
+				}
+			}
+		})
+	}
+}
+
+// batchExpressions builds a batch of representative expressions with one malformed entry
+func batchExpressions(size int) []string {
+	exprs := make([]string, size)
+	for i := range exprs {
+		exprs[i] = representativeExpressions[i%len(representativeExpressions)].expr
+	}
+	exprs[size/2] = "2 +"
+	return exprs
+}
+
+// BenchmarkEvaluateBatch compares batch evaluation throughput with an Evaluate loop
+func BenchmarkEvaluateBatch(b *testing.B) {
+	exprs := batchExpressions(1000)
+
+	b.Run("Batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = calculator.EvaluateBatch(exprs)
+		}
+	})
+
+	b.Run("Loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, expr := range exprs {
+				_, _ = calculator.Evaluate(expr)
+			}
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestBitwiseOperators tests bitwise operators on integer operands
+func TestBitwiseOperators(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"6 & 3", 2},
+		{"6 | 3", 7},
+		{"6 xor 3", 5},
+		{"1 << 4", 16},
+		{"256 >> 4", 16},
+		{"~0", -1},
+		{"~5", -6},
+		{"~~5", 5},
+		{"~-1", 0},
+		{"-8 >> 1", -4},
+		{"0xFF & 0b1010", 10},
+		{"6&3", 2},
+		{"1<<4", 16},
+		{"1 << 62", 1 << 62},
+		{"-1 << 63", -1 << 63},
+		{"0 << 100", 0},
+		{"-8 >> 70", -1},
+
+		// Bitwise operators bind looser than arithmetic
+		{"1 + 2 << 3", 24},
+		{"1 << 2 + 3", 32},
+		{"2 * 3 & 5", 4},
+		{"5 & 2 * 3", 4},
+
+		// | binds loosest, then xor, then &
+		{"1 | 2 & 3", 3},
+		{"1 | 6 xor 4", 3},
+		{"6 xor 3 & 1", 7},
+		{"(1 | 2) & 3", 3},
+
+		// Complement binds tighter than * but looser than ^
+		{"~2 * 3", -9},
+		{"2 * ~3", -8},
+		{"~2 ^ 2", -5},
+		{"10 - ~0", 11},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.Evaluate(tc.expression)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", tc.expression, tc.expected, result)
+		}
+	}
+}
+
+// TestBitwiseOperatorErrors tests that bitwise operators reject non-integer operands
+func TestBitwiseOperatorErrors(t *testing.T) {
+	testCases := []struct {
+		expression string
+		message    string
+	}{
+		{"2.5 & 1", "bitwise & requires integer operands, got 2.5"},
+		{"1 | 0.5", "bitwise | requires integer operands, got 0.5"},
+		{"~1.5", "bitwise ~ requires integer operands, got 1.5"},
+		{"1 << -1", "negative shift count: -1"},
+		{"1 << 64", "shift overflow: 1 << 64 exceeds the int64 range"},
+		{"1 << 63", "shift overflow"},
+		{"3 << 62", "shift overflow"},
+		{"-2 << 63", "shift overflow"},
+		{"2 ^ 70 & 1", "operand out of range for bitwise &"},
+		{"2 ~ 3", "invalid expression"},
+		{"2 <<< 3", "invalid expression"},
+	}
+
+	for _, tc := range testCases {
+		_, err := calculator.Evaluate(tc.expression)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s'", tc.expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("For expression '%s': expected error %q, got %q", tc.expression, tc.message, err.Error())
+		}
+	}
+}
+
+// TestBitwiseOtherModes tests bitwise operators in integer and postfix modes
+func TestBitwiseOtherModes(t *testing.T) {
+	result, err := calculator.EvaluateInt("(1 << 70 | 5) & ~1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.String() != "1180591620717411303428" {
+		t.Errorf("Expected 1180591620717411303428, got %s", result)
+	}
+
+	rpn, err := calculator.ToRPN("~6 & 3 xor 1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(rpn, " ") != "6 ~ 3 & 1 xor" {
+		t.Errorf("Expected %q, got %q", "6 ~ 3 & 1 xor", strings.Join(rpn, " "))
+	}
+
+	value, err := calculator.EvaluateRPN("6 ~ 3 & 1 xor")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value != 0 {
+		t.Errorf("Expected 0, got %v", value)
+	}
+}
@@ -0,0 +1,135 @@
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"github.com/dmisiuk/acousticalc/pkg/calculator/calctest"
+	"strings"
+	"testing"
+)
+
+// fakeTB records failures reported by the calctest helpers
+type fakeTB struct {
+	failures []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+// TestAssertEvaluates tests the result assertion helper for pass and fail cases
+func TestAssertEvaluates(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		want     float64
+		tol      float64
+		pass     bool
+		failText string
+	}{
+		{"Exact result", "2 + 3", 5, 0, true, ""},
+		{"Within tolerance", "0.1 + 0.2", 0.3, 1e-9, true, ""},
+		{"Outside tolerance", "0.1 + 0.2", 0.3, 0, false, `Evaluate("0.1 + 0.2") = 0.30000000000000004, want 0.3`},
+		{"Wrong result", "2 * 3", 5, 0.5, false, "want 5 (tolerance 0.5)"},
+		{"Evaluation error", "1 / 0", 0, 0, false, `returned error "division by zero"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeTB{}
+			if ok := calctest.AssertEvaluates(fake, tt.expr, tt.want, tt.tol); ok != tt.pass {
+				t.Errorf("Expected pass=%v, got %v (failures: %v)", tt.pass, ok, fake.failures)
+			}
+			checkFailures(t, fake, tt.pass, tt.failText)
+		})
+	}
+}
+
+// TestAssertErrors tests the error assertion helper for pass and fail cases
+func TestAssertErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		kind     calctest.ErrKind
+		pass     bool
+		failText string
+	}{
+		{"Division by zero", "1 / 0", calctest.ErrDivisionByZero, true, ""},
+		{"Modulo by zero", "1 % 0", calctest.ErrDivisionByZero, true, ""},
+		{"Syntax error", "2 +", calctest.ErrSyntax, true, ""},
+		{"Mismatched parentheses", "(2 + 3", calctest.ErrSyntax, true, ""},
+		{"Unknown function", "foo(1)", calctest.ErrUnknownName, true, ""},
+		{"Empty expression", "", calctest.ErrEmpty, true, ""},
+		{"Any error", "2 $ 3", calctest.ErrAny, true, ""},
+		{"No error", "2 + 3", calctest.ErrAny, false, `Evaluate("2 + 3") = 5, want any error`},
+		{"Wrong kind", "2 +", calctest.ErrDivisionByZero, false, "(syntax error), want division by zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeTB{}
+			if ok := calctest.AssertErrors(fake, tt.expr, tt.kind); ok != tt.pass {
+				t.Errorf("Expected pass=%v, got %v (failures: %v)", tt.pass, ok, fake.failures)
+			}
+			checkFailures(t, fake, tt.pass, tt.failText)
+		})
+	}
+
+	t.Run("Unclassified error", func(t *testing.T) {
+		if kind := calctest.KindOf(errors.New("something else")); kind != calctest.ErrAny {
+			t.Errorf("Expected ErrAny, got %s", kind)
+		}
+	})
+}
+
+// TestKindOf tests that errors are classified by the calculator's sentinel errors, even when wrapped
+func TestKindOf(t *testing.T) {
+	_, divErr := calculator.Evaluate("1 / 0")
+	_, nameErr := calculator.Evaluate("foo(1)")
+
+	tests := []struct {
+		name string
+		err  error
+		want calctest.ErrKind
+	}{
+		{"Nil error", nil, calctest.ErrNone},
+		{"Division by zero", divErr, calctest.ErrDivisionByZero},
+		{"Unknown function", nameErr, calctest.ErrUnknownName},
+		{"Wrapped division by zero", fmt.Errorf("line 3: %w", calculator.ErrDivisionByZero), calctest.ErrDivisionByZero},
+		{"Wrapped unknown identifier", fmt.Errorf("line 3: %w", calculator.ErrUnknownIdentifier), calctest.ErrUnknownName},
+		{"Wrapped empty expression", fmt.Errorf("line 3: %w", calculator.ErrEmptyExpression), calctest.ErrEmpty},
+		{"Same message, different error", errors.New("division by zero"), calctest.ErrAny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if kind := calctest.KindOf(tt.err); kind != tt.want {
+				t.Errorf("KindOf(%v) = %s, want %s", tt.err, kind, tt.want)
+			}
+		})
+	}
+
+	if !errors.Is(divErr, calculator.ErrDivisionByZero) {
+		t.Errorf("Expected Evaluate(\"1 / 0\") to return ErrDivisionByZero, got %v", divErr)
+	}
+}
+
+// checkFailures verifies the failures a helper reported on a fake TB
+func checkFailures(t *testing.T, fake *fakeTB, pass bool, failText string) {
+	t.Helper()
+	if pass {
+		if len(fake.failures) != 0 {
+			t.Errorf("Expected no failures, got %v", fake.failures)
+		}
+		return
+	}
+	if len(fake.failures) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", fake.failures)
+	}
+	if !strings.Contains(fake.failures[0], failText) {
+		t.Errorf("Expected failure containing %q, got %q", failText, fake.failures[0])
+	}
+}
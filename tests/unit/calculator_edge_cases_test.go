@@ -18,7 +18,7 @@ func TestCalculatorEdgeCases(t *testing.T) {
 		{"Complex nested with division by zero", "(5 + 3) * (2 - 1) / 0", true},
 		{"Multiple consecutive operators error", "2 ++ 3", true},
 		{"Operator at end error", "2 +", true},
-		{"Invalid character in middle", "2 & 3", true},
+		{"Invalid character in middle", "2 $ 3", true},
 		{"Multiple decimals", "3.14.15", true},
 		{"Unbalanced parentheses complex", "(2 + 3", true},
 		{"Extra closing parentheses", "2 + 3)", true},
@@ -103,7 +103,7 @@ func TestCalculatorErrorPaths(t *testing.T) {
 		{"Consecutive operators", "2 ++ 3"},
 		{"Trailing operator", "2 + 3 -"},
 		{"Leading operator", "+ 2 3"},
-		{"Invalid operator", "2 % 3"},
+		{"Invalid operator", "2 $ 3"},
 		{"Multiple decimal points", "3.14.15"},
 		{"Operator without operands", "2 3 +"},
 		{"Invalid characters", "2 @ 3"},
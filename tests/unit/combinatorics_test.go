@@ -0,0 +1,175 @@
+package unit
+
+import (
+	"fmt"
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestFactorialAndCombinatorics tests the postfix factorial and nCr/nPr functions
+func TestFactorialAndCombinatorics(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"0!", 1},
+		{"1!", 1},
+		{"5!", 120},
+		{"10!", 3628800},
+		{"3!!", 720},
+		{"(2 + 1)!", 6},
+		{"5! / 3!", 20},
+		{"2 * 3!", 12},
+		{"2 ^ 3!", 64},
+		{"4! + 1", 25},
+		{"nCr(5, 2)", 10},
+		{"nCr(5,2)", 10},
+		{"nCr(5, 0)", 1},
+		{"nCr(5, 5)", 1},
+		{"nCr(2, 5)", 0},
+		{"nCr(52, 5)", 2598960},
+		{"nCr(2 + 3, 1 + 1)", 10},
+		{"nPr(5, 2)", 20},
+		{"nPr(5, 0)", 1},
+		{"nPr(10, 3)", 720},
+		{"nCr(5, 2)!", 3628800},
+		{"sqrt(nPr(4, 2) + 4)", 4},
+
+		// Large n with a small k finishes and stays in range
+		{"nPr(10^20, 0)", 1},
+		{"nPr(10^17, 1)", 1e17},
+		{"nPr(10^17, 2)", 1e34},
+		{"nCr(10^20, 0)", 1},
+		{"nCr(10^17, 1)", 1e17},
+		{"nCr(10^15, 10^15 - 1)", 1e15},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.Evaluate(tc.expression)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", tc.expression, tc.expected, result)
+		}
+	}
+}
+
+// TestFactorialAndCombinatoricsErrors tests invalid arguments and overflow
+func TestFactorialAndCombinatoricsErrors(t *testing.T) {
+	testCases := []struct {
+		expression string
+		message    string
+	}{
+		{"(-3)!", "factorial of negative number: -3"},
+		{"2.5!", "factorial requires a non-negative integer, got 2.5"},
+		{"200!", "factorial overflow"},
+		{"171!", "factorial overflow"},
+		{"nCr(-1, 2)", "nCr of negative number: -1"},
+		{"nPr(5, 1.5)", "nPr requires a non-negative integer, got 1.5"},
+		{"nPr(1000, 500)", "nPr overflow"},
+		{"nCr(10^18, 10^17)", "nCr overflow"},
+		{"nPr(10^18, 10^17)", "nPr overflow"},
+		{"nCr(5)", "function nCr takes 2 argument(s), got 1"},
+		{"nCr(1, 2, 3)", "function nCr takes 2 argument(s), got 3"},
+		{"sqrt(4, 9)", "function sqrt takes 1 argument(s), got 2"},
+		{"nCr(5,)", "invalid expression"},
+		{"nCr(, 5)", "invalid expression"},
+		{"nCr(5 2)", "invalid expression"},
+		{"(1, 2)", "unexpected comma"},
+		{"!", "invalid expression"},
+	}
+
+	for _, tc := range testCases {
+		_, err := calculator.Evaluate(tc.expression)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s'", tc.expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("For expression '%s': expected error %q, got %q", tc.expression, tc.message, err.Error())
+		}
+	}
+
+	// 170! is the largest factorial that fits in a float64
+	if result, err := calculator.Evaluate("170!"); err != nil || result < 7.25e306 {
+		t.Errorf("Expected 170! to be about 7.26e306, got %v (error: %v)", result, err)
+	}
+}
+
+// TestCombinatoricsOtherModes tests factorial and nCr in integer and postfix modes
+func TestCombinatoricsOtherModes(t *testing.T) {
+	result, err := calculator.EvaluateInt("25! / nPr(25, 20)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.String() != "120" {
+		t.Errorf("Expected 120, got %s", result)
+	}
+
+	exact, err := calculator.EvaluateInt("nCr(100, 50)")
+	if err != nil || exact.String() != "100891344545564193334812497256" {
+		t.Errorf("Expected exact nCr(100, 50), got %v (error: %v)", exact, err)
+	}
+
+	rpn, err := calculator.ToRPN("nCr(5, 2) + 3!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(rpn, " ") != "5 2 nCr 3 ! +" {
+		t.Errorf("Expected %q, got %q", "5 2 nCr 3 ! +", strings.Join(rpn, " "))
+	}
+
+	value, err := calculator.EvaluateRPN("5 2 nCr 3 ! +")
+	if err != nil || value != 16 {
+		t.Errorf("Expected 16, got %v (error: %v)", value, err)
+	}
+}
+
+// TestNegatedFactorial tests that a leading minus applies after the factorial
+// in every evaluation mode, so that -3! is -(3!) rather than (-3)!
+func TestNegatedFactorial(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+		rpn        string
+	}{
+		{"-3!", -6, "-1 3 ! *"},
+		{"-2!", -2, "-1 2 ! *"},
+		{"- 3!", -6, "-1 3 ! *"},
+		{"2 * -3!", -12, "2 -1 3 ! * *"},
+		{"-3! + 1", -5, "-1 3 ! * 1 +"},
+		{"-3!!", -720, "-1 3 ! ! *"},
+		{"-3 != 3", 1, "-3 3 !="},
+	}
+
+	for _, tc := range testCases {
+		if result, err := calculator.Evaluate(tc.expression); err != nil || result != tc.expected {
+			t.Errorf("Evaluate(%q) = %v (error: %v), want %v", tc.expression, result, err, tc.expected)
+		}
+		if result, err := calculator.EvaluateInt(tc.expression); err != nil || result.Int64() != int64(tc.expected) {
+			t.Errorf("EvaluateInt(%q) = %v (error: %v), want %v", tc.expression, result, err, tc.expected)
+		}
+		if result, err := calculator.EvaluateDecimal(tc.expression, 64); err != nil || result != fmt.Sprint(tc.expected) {
+			t.Errorf("EvaluateDecimal(%q) = %v (error: %v), want %v", tc.expression, result, err, tc.expected)
+		}
+		if rpn, err := calculator.ToRPN(tc.expression); err != nil || strings.Join(rpn, " ") != tc.rpn {
+			t.Errorf("ToRPN(%q) = %v (error: %v), want %q", tc.expression, rpn, err, tc.rpn)
+		} else if result, err := calculator.EvaluateRPN(tc.rpn); err != nil || result != tc.expected {
+			t.Errorf("EvaluateRPN(%q) = %v (error: %v), want %v", tc.rpn, result, err, tc.expected)
+		}
+		if result, err := calculator.Eval(parseExpression(t, tc.expression)); err != nil || result != tc.expected {
+			t.Errorf("Eval of the syntax tree of %q = %v (error: %v), want %v", tc.expression, result, err, tc.expected)
+		}
+	}
+
+	// An explicitly negative operand is still rejected
+	if _, err := calculator.Evaluate("(-3)!"); err == nil || !strings.Contains(err.Error(), "factorial of negative number") {
+		t.Errorf("Expected (-3)! to be rejected, got %v", err)
+	}
+	if _, err := calculator.EvaluateDecimal("2.5!", 64); err == nil {
+		t.Error("Expected error for a non-integer factorial in decimal mode")
+	}
+}
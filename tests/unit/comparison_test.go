@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestComparisonOperators tests that comparisons evaluate to 1 or 0
+func TestComparisonOperators(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"3 > 2", 1},
+		{"3 < 2", 0},
+		{"2 >= 2", 1},
+		{"2 <= 1", 0},
+		{"2 == 2", 1},
+		{"2 != 2", 0},
+		{"-1 < -2", 0},
+		{"3>=2", 1},
+		{"1<=-1", 0},
+		{"5! == 120", 1},
+
+		// Equality tolerates floating point rounding error
+		{"0.1 + 0.2 == 0.3", 1},
+		{"0.1 + 0.2 != 0.3", 0},
+		{"0.1 + 0.2 <= 0.3", 1},
+		{"0.1 + 0.2 > 0.3", 0},
+		{"1 / 3 * 3 == 1", 1},
+		{"1 == 1.001", 0},
+
+		// The tolerance is relative, so small magnitudes still compare
+		{"0.0000000001 > 0", 1},
+		{"0.0000000001 != 0", 1},
+		{"0.0000000001 == 0", 0},
+		{"-0.0000000001 < 0", 1},
+		{"1e-12 < 2e-12", 1},
+		{"1e-12 == 1.0000000001e-12", 1},
+		{"1 - 0.9 - 0.1 == 0", 1},
+
+		// Comparisons bind looser than arithmetic and bitwise operators
+		{"1 + 1 == 2", 1},
+		{"2 * 3 > 5", 1},
+		{"10 - 4 < 2 ^ 2", 0},
+		{"1 << 2 >= 4", 1},
+		{"6 & 3 == 2", 1},
+		{"(2 > 1) + (3 > 1)", 2},
+		{"max(1 < 2, 3 > 4)", 1},
+
+		// Chains need parentheses
+		{"(1 < 2) < 3", 1},
+		{"3 > (2 > 1)", 1},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.Evaluate(tc.expression)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", tc.expression, tc.expected, result)
+		}
+	}
+}
+
+// TestComparisonErrors tests chained comparisons and malformed comparison operators
+func TestComparisonErrors(t *testing.T) {
+	testCases := []struct {
+		expression string
+		message    string
+	}{
+		{"1 < 2 < 3", "chained comparison, use parentheses"},
+		{"3 > 2 == 1", "chained comparison, use parentheses"},
+		{"1 == 1 == 1", "chained comparison, use parentheses"},
+		{"2 >", "invalid expression"},
+		{"2 <<< 3", "invalid expression"},
+	}
+
+	for _, tc := range testCases {
+		_, err := calculator.Evaluate(tc.expression)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s'", tc.expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("For expression '%s': expected error %q, got %q", tc.expression, tc.message, err.Error())
+		}
+	}
+}
+
+// TestComparisonOtherModes tests comparisons in integer, decimal, postfix and variable evaluation
+func TestComparisonOtherModes(t *testing.T) {
+	intResult, err := calculator.EvaluateInt("2 ^ 70 > 2 ^ 69")
+	if err != nil || intResult.Int64() != 1 {
+		t.Errorf("Expected EvaluateInt to give 1, got %v (error: %v)", intResult, err)
+	}
+
+	decimal, err := calculator.EvaluateDecimal("0.1 + 0.2 == 0.3", 200)
+	if err != nil || decimal != "1" {
+		t.Errorf("Expected EvaluateDecimal to give 1, got %q (error: %v)", decimal, err)
+	}
+
+	rpn, err := calculator.ToRPN("1 + 1 != 3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if actual := strings.Join(rpn, " "); actual != "1 1 + 3 !=" {
+		t.Errorf("Expected RPN '1 1 + 3 !=', got '%s'", actual)
+	}
+	if result, err := calculator.EvaluateRPN("1 1 + 3 !="); err != nil || result != 1 {
+		t.Errorf("Expected EvaluateRPN to give 1, got %v (error: %v)", result, err)
+	}
+
+	calc := calculator.New()
+	assertCalcResult(t, calc, "x = 3", 3)
+	assertCalcResult(t, calc, "x == 3", 1)
+	assertCalcResult(t, calc, "y = x > 2", 1)
+}
@@ -0,0 +1,145 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestConditionalOperator tests cond ? then : else expressions
+func TestConditionalOperator(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"3 > 2 ? 10 : 20", 10},
+		{"3 < 2 ? 10 : 20", 20},
+		{"5 ? 1 : 2", 1},
+		{"-0.5 ? 1 : 2", 1},
+		{"0 ? 1 : 2", 2},
+		{"1?2:3", 2},
+		{"0 ? 1 : -2", -2},
+
+		// Nested conditionals group from the right
+		{"0 ? 1 : 0 ? 2 : 3", 3},
+		{"0 ? 1 : 1 ? 2 : 3", 2},
+		{"1 ? 0 ? 5 : 6 : 7", 6},
+		{"(0 ? 1 : 1) ? 2 : 3", 2},
+
+		// The conditional binds looser than arithmetic and comparisons
+		{"1 + 1 ? 2 + 3 : 4", 5},
+		{"2 - 2 ? 10 : 20 * 2", 40},
+		{"1 == 2 ? 3 : 4 + 5", 9},
+		{"2 * (1 ? 3 : 4) + 1", 7},
+		{"max(0 ? 1 : 5, 2)", 5},
+
+		// The branch not taken is not evaluated
+		{"0 ? 1 / 0 : 5", 5},
+		{"1 ? 5 : 1 / 0", 5},
+		{"1 ? 5 : sqrt(undefined)", 5},
+		{"0 ? 1 ? 1 / 0 : 2 : 3", 3},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.Evaluate(tc.expression)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", tc.expression, tc.expected, result)
+		}
+	}
+}
+
+// TestConditionalOperatorErrors tests malformed conditionals
+func TestConditionalOperatorErrors(t *testing.T) {
+	testCases := []struct {
+		expression string
+		message    string
+	}{
+		{"1 ? 2", "missing : in conditional"},
+		{"(1 ? 2) : 3", "missing : in conditional"},
+		{"1 : 2", "unexpected : without ?"},
+		{"1 ? 2 : 3 : 4", "unexpected : without ?"},
+		{"? 1 : 2", "invalid expression"},
+		{"1 ? : 2", "invalid expression"},
+		{"1 ? 1 / 0 : 2", "division by zero"},
+	}
+
+	for _, tc := range testCases {
+		_, err := calculator.Evaluate(tc.expression)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s'", tc.expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("For expression '%s': expected error %q, got %q", tc.expression, tc.message, err.Error())
+		}
+	}
+}
+
+// TestConditionalOtherModes tests conditionals in integer, decimal, postfix, syntax tree and variable evaluation
+func TestConditionalOtherModes(t *testing.T) {
+	intResult, err := calculator.EvaluateInt("2 > 3 ? 7 / 2 : 2 ^ 70")
+	if err != nil || intResult.String() != "1180591620717411303424" {
+		t.Errorf("Expected EvaluateInt to give 2^70, got %v (error: %v)", intResult, err)
+	}
+
+	decimal, err := calculator.EvaluateDecimal("0.1 + 0.2 == 0.3 ? 1.5 : 0", 200)
+	if err != nil || decimal != "1.5" {
+		t.Errorf("Expected EvaluateDecimal to give 1.5, got %q (error: %v)", decimal, err)
+	}
+
+	rpn, err := calculator.ToRPN("1 < 2 ? 3 : 4")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if actual := strings.Join(rpn, " "); actual != "1 2 < 3 4 ?" {
+		t.Errorf("Expected RPN '1 2 < 3 4 ?', got '%s'", actual)
+	}
+	if result, err := calculator.EvaluateRPN("1 2 < 3 4 ?"); err != nil || result != 3 {
+		t.Errorf("Expected EvaluateRPN to give 3, got %v (error: %v)", result, err)
+	}
+
+	node := parseExpression(t, "x > 0 ? 1 / x : 0")
+	if actual := node.String(); actual != "x > 0 ? 1 / x : 0" {
+		t.Errorf("Expected the conditional to render as written, got %q", actual)
+	}
+	if result, err := calculator.Eval(parseExpression(t, "0 ? 1 / 0 : 4")); err != nil || result != 4 {
+		t.Errorf("Expected Eval to give 4, got %v (error: %v)", result, err)
+	}
+
+	calc := calculator.New()
+	assertCalcResult(t, calc, "x = 0", 0)
+	assertCalcResult(t, calc, "x != 0 ? 1 / x : -1", -1)
+	assertCalcResult(t, calc, "x = 4", 4)
+	assertCalcResult(t, calc, "x != 0 ? 1 / x : -1", 0.25)
+}
+
+// TestConditionalSkipsUntakenBranch tests that functions in the branch not taken are never called
+func TestConditionalSkipsUntakenBranch(t *testing.T) {
+	calls := 0
+	calc := calculator.New()
+	err := calc.RegisterFunction("tick", func(x float64) (float64, error) {
+		calls++
+		return x, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterFunction failed: %v", err)
+	}
+
+	for _, expression := range []string{"0 ? tick(1) : 2", "1 ? 2 : tick(1)", "1 ? (0 ? tick(1) : 2) : tick(3)", "0 ? tick(1) + tick(2)! : 2"} {
+		if _, err := calc.Eval(expression); err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", expression, err)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("Expected no calls from untaken branches, got %d", calls)
+	}
+
+	assertCalcResult(t, calc, "1 ? tick(5) : tick(6)", 5)
+	if calls != 1 {
+		t.Errorf("Expected only the taken branch to be called, got %d calls", calls)
+	}
+}
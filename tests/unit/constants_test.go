@@ -0,0 +1,74 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"github.com/dmisiuk/acousticalc/pkg/calculator/calctest"
+	"math"
+	"testing"
+)
+
+// TestConstants tests the pi and e identifiers
+func TestConstants(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Pi", "pi", 3.141592653589793},
+		{"E", "e", 2.718281828459045},
+		{"Pi times two", "pi * 2", 2 * math.Pi},
+		{"Two times pi", "2 * pi", 2 * math.Pi},
+		{"E squared", "e ^ 2", math.E * math.E},
+		{"Constant as function argument", "cos(pi)", -1},
+		{"Constant in parentheses", "(pi + e) - e", math.Pi},
+		{"Natural log of e", "ln(e)", 1},
+		{"Negative pi", "-pi", -math.Pi},
+		{"Negative e", "-e", -math.E},
+		{"Times negative pi", "2*-pi", -2 * math.Pi},
+		{"Negative pi in parentheses", "(-pi)", -math.Pi},
+		{"Negative constant as argument", "max(-pi, -e)", -math.E},
+		{"Minus negative constant", "1 - -e", 1 + math.E},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calctest.AssertEvaluates(t, tt.expression, tt.expected, 1e-9)
+		})
+	}
+}
+
+// TestUnaryMinus tests negating constants, function calls and parenthesized expressions
+func TestUnaryMinus(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Function call", "-sqrt(4)", -2},
+		{"Parenthesized", "-(2 + 3)", -5},
+		{"After an operator", "10 / -(1 + 1)", -5},
+		{"Binds looser than a power", "-pi ^ 2", -math.Pi * math.Pi},
+		{"Negative exponent", "2 ^ -(1 + 1)", 0.25},
+		{"Double negation", "- -pi", math.Pi},
+		{"Negated factorial", "-(3)!", -6},
+		{"Conditional branch", "1 ? -e : e", -math.E},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calctest.AssertEvaluates(t, tt.expression, tt.expected, 1e-9)
+		})
+	}
+
+	calc := calculator.New()
+	assertCalcResult(t, calc, "x = 3", 3)
+	assertCalcResult(t, calc, "-x * 2", -6)
+	assertCalcResult(t, calc, "y = -x", -3)
+}
+
+// TestUnknownIdentifier tests that names other than the constants still error
+func TestUnknownIdentifier(t *testing.T) {
+	for _, expression := range []string{"tau", "2 * x", "pie", "E"} {
+		calctest.AssertErrors(t, expression, calctest.ErrUnknownName)
+	}
+}
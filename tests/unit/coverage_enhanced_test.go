@@ -97,12 +97,8 @@ func TestEnhancedCoverageReporting(t *testing.T) {
 				if tc.category == "advanced" && (tc.name == "Floating point precision" ||
 					tc.name == "Decimal addition" || tc.name == "Decimal subtraction") {
 					// Allow for floating point precision issues
-					diff := result - tc.expected
-					if diff < 0 {
-						diff = -diff
-					}
-					if diff > 0.0000001 {
-						t.Errorf("Expected approximately %v, got %v (diff: %v)", tc.expected, result, diff)
+					if !calculator.AlmostEqual(result, tc.expected, 1e-9, 1e-7) {
+						t.Errorf("Expected approximately %v, got %v (diff: %v)", tc.expected, result, result-tc.expected)
 						return
 					}
 				} else {
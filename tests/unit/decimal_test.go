@@ -0,0 +1,119 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestEvaluateDecimal tests big.Float evaluation on inputs where float64 drifts
+func TestEvaluateDecimal(t *testing.T) {
+	testCases := []struct {
+		expression string
+		float64    string // float64 Evaluate result, formatted in full
+		decimal    string // EvaluateDecimal result at 200 bits
+	}{
+		{"0.1 + 0.2", "0.30000000000000004", "0.3"},
+		{"1.1 * 3", "3.3000000000000003", "3.3"},
+		{"(0.1 + 0.2) * 10", "3.0000000000000004", "3"},
+		{"0.7 + 0.1", "0.7999999999999999", "0.8"},
+		{"4.35 * 100", "434.99999999999994", "435"},
+		{"2 ^ 70 + 1", "1180591620717411300000", "1180591620717411303425"},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.Evaluate(tc.expression)
+		if err != nil {
+			t.Fatalf("Unexpected float64 error for expression '%s': %v", tc.expression, err)
+		}
+		if got := strconv.FormatFloat(result, 'f', -1, 64); got != tc.float64 {
+			t.Errorf("For expression '%s': expected float64 result %s, got %s", tc.expression, tc.float64, got)
+		}
+
+		decimal, err := calculator.EvaluateDecimal(tc.expression, 200)
+		if err != nil {
+			t.Fatalf("Unexpected decimal error for expression '%s': %v", tc.expression, err)
+		}
+		if decimal != tc.decimal {
+			t.Errorf("For expression '%s': expected decimal result %s, got %s", tc.expression, tc.decimal, decimal)
+		}
+	}
+}
+
+// TestEvaluateDecimalGrammar tests that decimal mode accepts the same grammar as Evaluate
+func TestEvaluateDecimalGrammar(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   string
+	}{
+		{"2 + 3 * 4", "14"},
+		{"2 ^ 3 ^ 2", "512"},
+		{"2 ^ -2", "0.25"},
+		{"7 // 2", "3"},
+		{"-7 // 2", "-4"},
+		{"-7 % 3", "2"},
+		{"200 * 15%", "30"},
+		{"0xFF & 0b1010", "10"},
+		{"~5", "-6"},
+		{"sqrt(2) ^ 2", "2"},
+		{"avg(0.1, 0.2, 0.3)", "0.2"},
+		{"max(1, 2.5) - min(0.5, 3)", "2"},
+		{"1 / 3", "0.333333333333333333333333333333333333333333333333333333333"},
+		{"pi", "3.14159265358979323846264338327950288419716939937510582097"},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.EvaluateDecimal(tc.expression, 200)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("For expression '%s': expected %s, got %s", tc.expression, tc.expected, result)
+		}
+	}
+
+	// Lower precision shows fewer digits
+	if result, err := calculator.EvaluateDecimal("1 / 3", 53); err != nil || result != "0.333333333333" {
+		t.Errorf("Expected 12 digits at 53 bits, got %s (error: %v)", result, err)
+	}
+}
+
+// TestEvaluateDecimalErrors tests errors in decimal mode
+func TestEvaluateDecimalErrors(t *testing.T) {
+	testCases := []struct {
+		expression string
+		prec       uint
+		message    string
+	}{
+		{"1 / 0", 100, "division by zero"},
+		{"5 % 0", 100, "division by zero"},
+		{"2 ^ 0.5", 100, "non-integer exponent"},
+		{"sqrt(-1)", 100, "square root of negative number"},
+		{"sin(1)", 100, "function sin is not supported in decimal mode"},
+		{"2 +", 100, "invalid expression"},
+		{"", 100, "empty expression"},
+		{"1 + 1", 0, "precision must be positive"},
+
+		// Results beyond the big.Float exponent range are errors, not panics
+		{"10 ^ 2147483647", 100, "overflow"},
+		{"10 ^ 2147483647 - 10 ^ 2147483647", 100, "overflow"},
+		{"0 * 10 ^ 2147483647", 100, "overflow"},
+		{"0.5 ^ -2147483647", 100, "overflow"},
+		{"(2 ^ 1073741823) * (2 ^ 1073741823) * 4", 100, "overflow"},
+		{"(2 ^ 1073741823) * (2 ^ 1073741823) * 4 - 1", 100, "overflow"},
+		{"sum(2 ^ 2147483646, 2 ^ 2147483646)", 100, "overflow"},
+	}
+
+	for _, tc := range testCases {
+		_, err := calculator.EvaluateDecimal(tc.expression, tc.prec)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s'", tc.expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("For expression '%s': expected error %q, got %q", tc.expression, tc.message, err.Error())
+		}
+	}
+}
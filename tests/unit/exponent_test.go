@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestExponentiation tests the right-associative ^ operator
+func TestExponentiation(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Simple power", "2 ^ 3", 8},
+		{"Right associative", "2 ^ 3 ^ 2", 512},
+		{"Binds tighter than multiplication", "2 * 3 ^ 2", 18},
+		{"Binds tighter than division", "18 / 3 ^ 2", 2},
+		{"Binds tighter than addition", "1 + 2 ^ 3", 9},
+		{"Negative exponent", "2 ^ -1", 0.5},
+		{"Zero to the zero", "0 ^ 0", 1},
+		{"Fractional exponent", "16 ^ 0.5", 4},
+		{"Parentheses group left", "(2 ^ 3) ^ 2", 64},
+		{"Parenthesised base", "(1 + 2) ^ 2", 9},
+		{"Parenthesised exponent", "2 ^ (1 + 2)", 8},
+		{"Without spaces", "2^10", 1024},
+		{"Chained with modulo", "2 ^ 5 % 7", 4},
+
+		// A leading minus negates the power, not the base
+		{"Negated power", "-2^2", -4},
+		{"Negated power with spaces", "-2 ^ 2", -4},
+		{"Negated power after operator", "10 - -2 ^ 2", 14},
+		{"Negated power as exponent", "2 ^ -2 ^ 2", 0.0625},
+		{"Parenthesised negative base", "(-2) ^ 2", 4},
+		{"Negative literal without power", "-2 * 2", -4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calculator.Evaluate(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error for expression '%s': %v", tt.expression, err)
+			}
+			if result != tt.expected {
+				t.Errorf("For expression '%s': expected %v, got %v", tt.expression, tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestExponentiationErrors tests malformed power expressions
+func TestExponentiationErrors(t *testing.T) {
+	for _, expression := range []string{"2 ^", "^ 2", "2 ^ ^ 3"} {
+		if _, err := calculator.Evaluate(expression); err == nil {
+			t.Errorf("Expected error for expression '%s', got nil", expression)
+		}
+	}
+}
+
+// TestNegatedPowerOtherModes tests that -2^2 is -(2^2) in integer, decimal and postfix evaluation
+func TestNegatedPowerOtherModes(t *testing.T) {
+	intResult, err := calculator.EvaluateInt("-2 ^ 2")
+	if err != nil || intResult.Int64() != -4 {
+		t.Errorf("Expected EvaluateInt to give -4, got %v (error: %v)", intResult, err)
+	}
+
+	decimal, err := calculator.EvaluateDecimal("-2 ^ 2", 100)
+	if err != nil || decimal != "-4" {
+		t.Errorf("Expected EvaluateDecimal to give -4, got %q (error: %v)", decimal, err)
+	}
+
+	rpn, err := calculator.ToRPN("-2 ^ 2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	result, err := calculator.EvaluateRPN(strings.Join(rpn, " "))
+	if err != nil || result != -4 {
+		t.Errorf("Expected postfix %v to give -4, got %v (error: %v)", rpn, result, err)
+	}
+}
@@ -0,0 +1,55 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"testing"
+)
+
+// TestFloorDivision tests the // operator rounds the quotient towards negative infinity
+func TestFloorDivision(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Positive operands", "7 // 2", 3},
+		{"Exact division", "8 // 2", 4},
+		{"Negative dividend floors down", "-7 // 2", -4},
+		{"Negative divisor floors down", "7 // -2", -4},
+		{"Both negative", "-7 // -2", 3},
+		{"Float operands", "7.5 // 2", 3},
+		{"Float divisor", "1 // 0.3", 3},
+		{"Multiplicative precedence", "1 + 7 // 2", 4},
+		{"Left associative with division", "20 / 2 // 3", 3},
+		{"Without spaces", "9//4", 2},
+		{"Parenthesised operand", "(3 + 4) // 2", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calculator.Evaluate(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error for expression '%s': %v", tt.expression, err)
+			}
+			if result != tt.expected {
+				t.Errorf("For expression '%s': expected %v, got %v", tt.expression, tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFloorDivisionErrors tests error handling for the // operator
+func TestFloorDivisionErrors(t *testing.T) {
+	_, err := calculator.Evaluate("7 // 0")
+	if err == nil {
+		t.Fatal("Expected division by zero error")
+	}
+	if err.Error() != "division by zero" {
+		t.Errorf("Expected 'division by zero' error, got %v", err)
+	}
+
+	// Slashes separated by whitespace are two operators, not floor division
+	if _, err := calculator.Evaluate("7 / / 2"); err == nil {
+		t.Error("Expected syntax error for '7 / / 2'")
+	}
+}
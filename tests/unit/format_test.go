@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"math"
+	"testing"
+)
+
+// TestFormatResult tests formatting results with separators, decimal places and scientific notation
+func TestFormatResult(t *testing.T) {
+	places := func(n int) *int { return &n }
+	grouped := calculator.FormatOptions{ThousandsSeparator: ","}
+	twoPlaces := calculator.FormatOptions{ThousandsSeparator: ",", DecimalPlaces: places(2)}
+	scientific := calculator.FormatOptions{ThousandsSeparator: ",", ScientificThreshold: 1e15}
+
+	testCases := []struct {
+		name     string
+		value    float64
+		opts     calculator.FormatOptions
+		expected string
+	}{
+		{"Thousands separators", 1234567.5, grouped, "1,234,567.5"},
+		{"Small number is not grouped", 999, grouped, "999"},
+		{"Exact group boundary", 100000, grouped, "100,000"},
+		{"Negative number", -1234567.25, grouped, "-1,234,567.25"},
+		{"Negative below a thousand", -12.5, grouped, "-12.5"},
+		{"Integer has no trailing .0", 42, grouped, "42"},
+		{"Zero", 0, grouped, "0"},
+		{"Shortest keeps every digit", 2.0 / 3, grouped, "0.6666666666666666"},
+		{"Zero value options", 1234567.5, calculator.FormatOptions{}, "1234567.5"},
+		{"Zero value keeps every digit", 2.0 / 3, calculator.FormatOptions{}, "0.6666666666666666"},
+		{"Other separator", 1234567, calculator.FormatOptions{ThousandsSeparator: " "}, "1 234 567"},
+
+		{"Rounded to two places", 1234.5678, twoPlaces, "1,234.57"},
+		{"Trailing zeros dropped", 1234.5, twoPlaces, "1,234.5"},
+		{"Rounded integer has no point", 1999.999, twoPlaces, "2,000"},
+		{"Negative rounds to zero", -0.001, twoPlaces, "0"},
+		{"Zero places", 2.5e6 + 0.7, calculator.FormatOptions{ThousandsSeparator: ",", DecimalPlaces: places(0)}, "2,500,001"},
+		{"Half rounds away from zero", 2.5, calculator.FormatOptions{DecimalPlaces: places(0)}, "3"},
+		{"Negative half rounds away from zero", -2.5, calculator.FormatOptions{DecimalPlaces: places(0)}, "-3"},
+		{"Half of an exact binary fraction rounds up", 0.125, twoPlaces, "0.13"},
+		{"Half as written rounds up", 1.005, twoPlaces, "1.01"},
+		{"Negative places keep every digit", 1234.5678, calculator.FormatOptions{DecimalPlaces: places(-1)}, "1234.5678"},
+
+		{"Below scientific threshold", 123456789012345, scientific, "123,456,789,012,345"},
+		{"Large value is scientific", 1.5e20, scientific, "1.5e+20"},
+		{"Negative large value", -2e15, scientific, "-2e+15"},
+		{"Scientific with places", 1.23456e18, calculator.FormatOptions{DecimalPlaces: places(2), ScientificThreshold: 1e15}, "1.23e+18"},
+		{"Very large without threshold", 1e21, grouped, "1,000,000,000,000,000,000,000"},
+
+		{"NaN", math.NaN(), scientific, "NaN"},
+		{"Infinity", math.Inf(1), scientific, "+Inf"},
+		{"Negative infinity", math.Inf(-1), grouped, "-Inf"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := calculator.FormatResult(tc.value, tc.opts); actual != tc.expected {
+				t.Errorf("FormatResult(%v): expected %q, got %q", tc.value, tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"github.com/dmisiuk/acousticalc/pkg/calculator/calctest"
+	"strings"
+	"testing"
+)
+
+// TestBuiltinFunctions tests function-call syntax for the built-in math functions
+func TestBuiltinFunctions(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Square root", "sqrt(16)", 4},
+		{"Sine", "sin(0)", 0},
+		{"Cosine", "cos(0)", 1},
+		{"Base 10 logarithm", "log(100)", 2},
+		{"Natural logarithm", "ln(1)", 0},
+		{"Nested calls", "sqrt(sin(0) + 16)", 4},
+		{"Expression argument", "sqrt(3 * 3 + 16)", 5},
+		{"Call in expression", "2 * sqrt(9) + 1", 7},
+		{"Call binds before power", "sqrt(4) ^ 3", 8},
+		{"Spaces before argument list", "sqrt (25)", 5},
+		{"Parenthesised argument", "sqrt((2 + 2) * 4)", 4},
+		{"Multiple calls", "log(1000) - ln(1) + cos(0)", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calctest.AssertEvaluates(t, tt.expression, tt.expected, 1e-12)
+		})
+	}
+}
+
+// TestBuiltinFunctionErrors tests unknown names and malformed calls
+func TestBuiltinFunctionErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		errorText  string
+	}{
+		{"Unknown function", "foo(2)", "unknown function: foo"},
+		{"Nested unknown function", "sqrt(foo(2))", "unknown function: foo"},
+		{"Missing argument", "sqrt()", "missing argument for function sqrt"},
+		{"Missing argument list", "sqrt 16", "unknown identifier: sqrt"},
+		{"Bare identifier", "2 + a", "unknown identifier: a"},
+		{"Unclosed call", "sqrt(16", "mismatched parentheses"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calculator.Evaluate(tt.expression)
+			if err == nil {
+				t.Fatalf("Expected error for expression '%s', got nil", tt.expression)
+			}
+			if !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorText, err.Error())
+			}
+		})
+	}
+}
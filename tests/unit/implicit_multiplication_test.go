@@ -0,0 +1,112 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"github.com/dmisiuk/acousticalc/pkg/calculator/calctest"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestImplicitMultiplication tests that juxtaposed operands are multiplied
+func TestImplicitMultiplication(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"2(3+4)", 14},
+		{"2 (3 + 4)", 14},
+		{"(2)(3)", 6},
+		{"(1 + 1)(2 + 2)(3)", 24},
+		{"2pi", 2 * math.Pi},
+		{"2 pi", 2 * math.Pi},
+		{"(2)pi", 2 * math.Pi},
+		{"3sqrt(16)", 12},
+		{"-2(3)", -6},
+		{"2(-3)", -6},
+
+		// Implied multiplication binds exactly like an explicit *
+		{"1/2(3)", 1.5},
+		{"6/2(1+2)", 9},
+		{"1 + 2(3)", 7},
+		{"2(3)^2", 18},
+		{"2 - 3(4)", -10},
+	}
+
+	for _, tc := range testCases {
+		calctest.AssertEvaluates(t, tc.expression, tc.expected, 1e-12)
+	}
+}
+
+// TestImplicitMultiplicationModes tests implied multiplication outside float64 evaluation
+func TestImplicitMultiplicationModes(t *testing.T) {
+	intResult, err := calculator.EvaluateInt("3(4 + 5)")
+	if err != nil || intResult.Int64() != 27 {
+		t.Errorf("Expected EvaluateInt to give 27, got %v (error: %v)", intResult, err)
+	}
+
+	rpn, err := calculator.ToRPN("2(3+4)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if actual := strings.Join(rpn, " "); actual != "2 3 4 + *" {
+		t.Errorf("Expected RPN '2 3 4 + *', got '%s'", actual)
+	}
+
+	calc := calculator.New()
+	if _, err := calc.Eval("x = 5"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertCalcResult(t, calc, "2x", 10)
+	assertCalcResult(t, calc, "(x)(x)", 25)
+}
+
+// TestScientificNotation tests that an e followed by an exponent is part of
+// the number rather than an implied multiplication by the constant e
+func TestScientificNotation(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"1e3", 1000},
+		{"2e-3", 0.002},
+		{"1.5E+2", 150},
+		{"1/1e-320", math.Inf(1)},
+		{"-2e2 ^ 2", -40000},
+		{"3e2 - 1", 299},
+
+		// Without an exponent e is still the constant
+		{"2e", 2 * math.E},
+		{"2 e", 2 * math.E},
+		{"2e^2", 2 * math.E * math.E},
+		{"0x1e-2", 28},
+	}
+
+	for _, tc := range testCases {
+		calctest.AssertEvaluates(t, tc.expression, tc.expected, 1e-12)
+	}
+
+	rpn, err := calculator.ToRPN("1/1e-320")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if actual := strings.Join(rpn, " "); actual != "1 1e-320 /" {
+		t.Errorf("Expected RPN '1 1e-320 /', got '%s'", actual)
+	}
+}
+
+// TestImplicitMultiplicationErrors tests juxtapositions that stay invalid
+func TestImplicitMultiplicationErrors(t *testing.T) {
+	testCases := []string{
+		"2 3",   // two numbers need an explicit operator
+		"(2)3",  // so does a number after a parenthesis
+		"pi(2)", // an identifier before "(" is a function call
+		"2()",
+	}
+
+	for _, expression := range testCases {
+		if result, err := calculator.Evaluate(expression); err == nil {
+			t.Errorf("Expected error for expression '%s', got %v", expression, result)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestEvaluateInt tests exact integer evaluation
+func TestEvaluateInt(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{"Addition", "2 + 3", "5"},
+		{"Precedence", "2 + 3 * 4", "14"},
+		{"Product exceeding int64", "9223372036854775807 * 9223372036854775807", "85070591730234615847396907784232501249"},
+		{"Chained large product", "4294967296 * 4294967296 * 4294967296", "79228162514264337593543950336"},
+		{"Large subtraction", "100000000000000000000 - 1", "99999999999999999999"},
+		{"Exact division", "12 / 4", "3"},
+		{"Floor division", "7 // 2", "3"},
+		{"Floor division negative dividend", "-7 // 2", "-4"},
+		{"Floor division negative divisor", "7 // -2", "-4"},
+		{"Floor division both negative", "-7 // -2", "3"},
+		{"Modulo", "17 % 5", "2"},
+		{"Modulo negative dividend", "-7 % 3", "2"},
+		{"Modulo of large product", "9223372036854775807 * 3 % 1000", "421"},
+		{"Power exceeding int64", "2 ^ 100", "1267650600228229401496703205376"},
+		{"Power is right associative", "2 ^ 3 ^ 2", "512"},
+		{"Parentheses", "(2 + 3) * 4", "20"},
+		{"Negative number", "-5 + 3", "-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calculator.EvaluateInt(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error for expression '%s': %v", tt.expression, err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("For expression '%s': expected %s, got %s", tt.expression, tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestEvaluateIntErrors tests rejection of non-integer input and results
+func TestEvaluateIntErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{"Division producing a fraction", "7 / 2"},
+		{"Float literal", "1.5 + 1"},
+		{"Exponent literal", "1e3"},
+		{"Division by zero", "1 / 0"},
+		{"Floor division by zero", "1 // 0"},
+		{"Modulo by zero", "1 % 0"},
+		{"Negative exponent", "2 ^ -1"},
+		{"Function call", "sqrt(16)"},
+		{"Constant", "2 * pi"},
+		{"Empty expression", ""},
+		{"Mismatched parentheses", "(1 + 2"},
+		{"Invalid expression", "2 +"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := calculator.EvaluateInt(tt.expression); err == nil {
+				t.Errorf("Expected error for expression '%s', got nil", tt.expression)
+			}
+		})
+	}
+}
+
+// TestEvaluateIntPowerLimit tests that powers too large to compute are rejected
+func TestEvaluateIntPowerLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{"Bounded power", "3 ^ 40", "12157665459056928801"},
+		{"Power near the limit", "2 ^ 500000 // 2 ^ 499999", "2"},
+		{"One to a huge power", "1 ^ (2 ^ 40)", "1"},
+		{"Minus one to an odd huge power", "(-1) ^ (2 ^ 40 + 1)", "-1"},
+		{"Zero to a huge power", "0 ^ (2 ^ 40)", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calculator.EvaluateInt(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error for expression '%s': %v", tt.expression, err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("For expression '%s': expected %s, got %s", tt.expression, tt.expected, result)
+			}
+		})
+	}
+
+	for _, expression := range []string{"2 ^ 2 ^ 40", "10 ^ 1000000", "(-3) ^ (2 ^ 70)"} {
+		_, err := calculator.EvaluateInt(expression)
+		if err == nil || !strings.Contains(err.Error(), "exponent too large") {
+			t.Errorf("Expected exponent too large error for expression '%s', got %v", expression, err)
+		}
+	}
+}
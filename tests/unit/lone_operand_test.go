@@ -0,0 +1,71 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"math"
+	"testing"
+)
+
+// TestLoneOperands tests expressions without any operator
+func TestLoneOperands(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Lone number", "42", 42},
+		{"Lone decimal", "3.25", 3.25},
+		{"Lone negative number", "-7", -7},
+		{"Lone number with spaces", "  42  ", 42},
+		{"Lone number in parentheses", "(42)", 42},
+		{"Lone hexadecimal literal", "0x2A", 42},
+		{"Lone scientific notation", "1e5", 100000},
+		{"Lone constant", "pi", math.Pi},
+		{"Lone constant e", "e", math.E},
+		{"Lone function call", "sqrt(16)", 4},
+		{"Lone function call with arguments", "max(1, 42)", 42},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := calculator.Evaluate(tc.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+
+	for _, expression := range []string{".", "-", "1.2.3", "1e+", "inf", "unknown"} {
+		if _, err := calculator.Evaluate(expression); err == nil {
+			t.Errorf("Expected error for expression '%s'", expression)
+		}
+	}
+}
+
+// TestLoneVariable tests evaluating a lone variable on a Calculator
+func TestLoneVariable(t *testing.T) {
+	calc := calculator.New()
+	if _, err := calc.Eval("x = 6 * 7"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, expression := range []string{"x", " x ", "ans"} {
+		result, err := calc.Eval(expression)
+		if err != nil {
+			t.Fatalf("Unexpected error for expression '%s': %v", expression, err)
+		}
+		if result != 42 {
+			t.Errorf("For expression '%s': expected 42, got %v", expression, result)
+		}
+	}
+
+	if result, err := calc.Eval("pi"); err != nil || result != math.Pi {
+		t.Errorf("Expected pi, got %v (error: %v)", result, err)
+	}
+	if _, err := calc.Eval("y"); err == nil || err.Error() != "unknown identifier: y" {
+		t.Errorf("Expected unknown identifier error, got %v", err)
+	}
+}
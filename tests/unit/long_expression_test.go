@@ -0,0 +1,51 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// longChainTerms is the number of terms in the generated chains, far beyond
+// what a recursive descent parser could nest
+const longChainTerms = 10000
+
+// repeatTerm joins n copies of term with op
+func repeatTerm(term, op string, n int) string {
+	terms := make([]string, n)
+	for i := range terms {
+		terms[i] = term
+	}
+	return strings.Join(terms, op)
+}
+
+// TestLongOperatorChains tests that long left-associative chains are evaluated
+// iteratively by the operator stack rather than by recursion
+func TestLongOperatorChains(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Sum", repeatTerm("1", "+", longChainTerms), longChainTerms},
+		{"Sum with spaces", repeatTerm("1", " + ", longChainTerms), longChainTerms},
+		{"Difference", "20000" + strings.Repeat("-1", longChainTerms), 10000},
+		{"Product", repeatTerm("1", "*", longChainTerms), 1},
+		{"Quotient", "1024" + strings.Repeat("/1", longChainTerms), 1024},
+		{"Mixed precedence", repeatTerm("2*3", "+", longChainTerms), 6 * longChainTerms},
+		{"Right associative power", repeatTerm("1", "^", longChainTerms), 1},
+		{"Nested parentheses", strings.Repeat("(", longChainTerms) + "1" + strings.Repeat("+1)", longChainTerms), longChainTerms + 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := calculator.Evaluate(tc.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"testing"
+)
+
+// TestModulo tests the % operator using floored modulo, consistent with //
+func TestModulo(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Positive operands", "17 % 5", 2},
+		{"Integer division companion", "17 // 5", 3},
+		{"Exact multiple", "15 % 5", 0},
+		{"Negative dividend", "-7 % 3", 2},
+		{"Negative divisor", "7 % -3", -2},
+		{"Both negative", "-7 % -3", -1},
+		{"Float operands", "7.5 % 2", 1.5},
+		{"Multiplicative precedence", "1 + 17 % 5", 3},
+		{"Left associative with multiplication", "2 * 17 % 5", 4},
+		{"Without spaces", "17%5", 2},
+		{"Parenthesised operand", "(10 + 7) % 5", 2},
+		{"Division and modulo identity", "(-7 // 3) * 3 + -7 % 3", -7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calculator.Evaluate(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error for expression '%s': %v", tt.expression, err)
+			}
+			if result != tt.expected {
+				t.Errorf("For expression '%s': expected %v, got %v", tt.expression, tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestModuloByZero tests that modulo by zero reports the same error as division by zero
+func TestModuloByZero(t *testing.T) {
+	_, divErr := calculator.Evaluate("1 / 0")
+	for _, expression := range []string{"1 % 0", "5 % (2 - 2)", "-3 % 0"} {
+		_, err := calculator.Evaluate(expression)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s', got nil", expression)
+			continue
+		}
+		if err.Error() != divErr.Error() {
+			t.Errorf("Expected %q for expression '%s', got %q", divErr.Error(), expression, err.Error())
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"github.com/dmisiuk/acousticalc/pkg/calculator/calctest"
+	"math"
+	"testing"
+)
+
+// TestPostfixPercent tests % as a postfix percentage when no operand follows it
+func TestPostfixPercent(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Bare percentage", "50%", 0.5},
+		{"Percentage of a product", "200 * 15%", 30},
+		{"Sum of percentages", "100% + 50%", 1.5},
+		{"Parenthesised percentage", "(20 + 30)%", 0.5},
+		{"Added percentage is divided by 100", "200 + 10%", 200.1},
+		{"Percentage before closing parenthesis", "(10% + 1) * 2", 2.2},
+		{"Percentage of a function call", "sqrt(100)%", 0.1},
+		{"Percentage of a constant", "pi% * 100", math.Pi},
+		{"Modulo between operands", "17 % 5", 2},
+		{"Modulo then percentage", "3 % 200%", 1},
+		{"Percentage then modulo", "300% % 2", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calctest.AssertEvaluates(t, tt.expression, tt.expected, 1e-12)
+		})
+	}
+}
+
+// TestPostfixPercentErrors tests percent signs without an operand
+func TestPostfixPercentErrors(t *testing.T) {
+	for _, expression := range []string{"%", "(%)", "2 + %"} {
+		if _, err := calculator.Evaluate(expression); err == nil {
+			t.Errorf("Expected error for expression '%s', got nil", expression)
+		}
+	}
+}
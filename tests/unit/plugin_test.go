@@ -0,0 +1,138 @@
+package unit
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestProviderHelperProcess is not a real test: it is the fake function
+// provider run as a subprocess by the plugin tests
+func TestProviderHelperProcess(t *testing.T) {
+	mode := os.Getenv("ACOUSTICALC_PROVIDER_HELPER")
+	if mode == "" {
+		return
+	}
+	defer os.Exit(0)
+
+	var request struct {
+		Func string    `json:"func"`
+		Args []float64 `json:"args"`
+	}
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	switch {
+	case mode == "sleep":
+		time.Sleep(10 * time.Second)
+	case request.Func == "double":
+		fmt.Printf(`{"result":%v}`, request.Args[0]*2)
+	case request.Func == "add":
+		fmt.Printf(`{"result":%v}`, request.Args[0]+request.Args[1])
+	default:
+		fmt.Printf(`{"error":"unknown function %s"}`, request.Func)
+	}
+}
+
+// fakeProvider returns a provider that runs this test binary as the helper
+func fakeProvider(mode string) *calculator.SubprocessProvider {
+	provider := calculator.NewSubprocessProvider(os.Args[0], "-test.run=^TestProviderHelperProcess$")
+	provider.Env = append(os.Environ(), "ACOUSTICALC_PROVIDER_HELPER="+mode)
+	return provider
+}
+
+// TestSubprocessProvider tests calling functions served by an external helper
+func TestSubprocessProvider(t *testing.T) {
+	calc := calculator.New()
+	if err := calc.RegisterProvider(fakeProvider("serve"), 1, "double", "triple"); err != nil {
+		t.Fatalf("Failed to register provider: %v", err)
+	}
+	if err := calc.RegisterProvider(fakeProvider("serve"), 2, "add"); err != nil {
+		t.Fatalf("Failed to register provider: %v", err)
+	}
+
+	result, err := calc.Eval("double(21)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != 42 {
+		t.Errorf("Expected double(21) = 42, got %v", result)
+	}
+
+	if result, err := calc.Eval("1 + double(2 * 3)"); err != nil || result != 13 {
+		t.Errorf("Expected 13, got %v (error: %v)", result, err)
+	}
+
+	if result, err := calc.Eval("add(double(2), 3)"); err != nil || result != 7 {
+		t.Errorf("Expected add(double(2), 3) = 7, got %v (error: %v)", result, err)
+	}
+
+	_, err = calc.Eval("add(1)")
+	if err == nil || !strings.Contains(err.Error(), "function add takes 2 argument(s), got 1") {
+		t.Errorf("Expected arity error, got %v", err)
+	}
+
+	_, err = calc.Eval("triple(2)")
+	if err == nil || !strings.Contains(err.Error(), "unknown function triple") {
+		t.Errorf("Expected provider error to propagate, got %v", err)
+	}
+
+	if err := calc.RegisterProvider(fakeProvider("serve"), 0, "none"); err == nil {
+		t.Error("Expected error registering a provider function without arguments")
+	}
+}
+
+// TestSubprocessProviderTimeout tests that a hanging helper is stopped
+func TestSubprocessProviderTimeout(t *testing.T) {
+	provider := fakeProvider("sleep")
+	provider.Timeout = 200 * time.Millisecond
+
+	start := time.Now()
+	_, err := provider.Call("double", 21)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Timeout took too long: %v", elapsed)
+	}
+}
+
+// TestRegisterFunction tests registering in-process functions
+func TestRegisterFunction(t *testing.T) {
+	calc := calculator.New()
+	square := func(x float64) (float64, error) { return x * x, nil }
+
+	if err := calc.RegisterFunction("square", square); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result, err := calc.Eval("square(3) + 1"); err != nil || result != 10 {
+		t.Errorf("Expected 10, got %v (error: %v)", result, err)
+	}
+
+	for _, name := range []string{"sqrt", "pi", "ans", "1x", "xor", "neg", "a b", "f-1", "f(x)", ""} {
+		if err := calc.RegisterFunction(name, square); err == nil {
+			t.Errorf("Expected error registering %q", name)
+		}
+	}
+
+	// Digits and underscores may follow the first letter
+	for _, name := range []string{"f1", "_g", "log_2"} {
+		if err := calc.RegisterFunction(name, square); err != nil {
+			t.Errorf("Unexpected error registering %q: %v", name, err)
+		}
+	}
+	if result, err := calc.Eval("log_2(3) + f1(2)"); err != nil || result != 13 {
+		t.Errorf("Expected 13, got %v (error: %v)", result, err)
+	}
+
+	// Functions registered on one calculator are not visible to Evaluate
+	if _, err := calculator.Evaluate("square(3)"); err == nil {
+		t.Error("Expected unknown function error from Evaluate")
+	}
+}
@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestRadixLiterals tests hexadecimal, binary and octal integer literals
+func TestRadixLiterals(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"0xFF", 255},
+		{"0xff", 255},
+		{"0XfF", 255},
+		{"0b1010", 10},
+		{"0B1010", 10},
+		{"0o17", 15},
+		{"0O17", 15},
+		{"0xFF + 0b1010", 265},
+		{"0x10 * 2", 32},
+		{"0x10*2", 32},
+		{"-0x10 + 1", -15},
+		{"(0b11 + 0o7) * 0xA", 100},
+		{"0", 0},
+		{"017", 17}, // a leading zero alone is still decimal
+		{"0.5 + 0x1", 1.5},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.Evaluate(tc.expression)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", tc.expression, tc.expected, result)
+		}
+	}
+}
+
+// TestMalformedRadixLiterals tests that invalid digits for the base are rejected
+// and that literals too large for 64 bits are reported as out of range
+func TestMalformedRadixLiterals(t *testing.T) {
+	testCases := []struct {
+		expression string
+		message    string
+	}{
+		{"0xG1", "malformed hexadecimal literal: 0xG1"},
+		{"0x", "malformed hexadecimal literal: 0x"},
+		{"0b102", "malformed binary literal: 0b102"},
+		{"0o8", "malformed octal literal: 0o8"},
+		{"1 + 0x1.5", "malformed hexadecimal literal: 0x1.5"},
+
+		// Valid literals too large for float64 mode are out of range, not malformed
+		{"0x10000000000000000", "hexadecimal literal out of range: 0x10000000000000000 exceeds 64 bits"},
+		{"-0x1FFFFFFFFFFFFFFFF", "hexadecimal literal out of range: -0x1FFFFFFFFFFFFFFFF"},
+		{"0b" + strings.Repeat("1", 65), "binary literal out of range"},
+		{"0o2000000000000000000000", "octal literal out of range"},
+	}
+
+	for _, tc := range testCases {
+		_, err := calculator.Evaluate(tc.expression)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s'", tc.expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("For expression '%s': expected error %q, got %q", tc.expression, tc.message, err.Error())
+		}
+	}
+}
+
+// TestRadixLiteralsIntegerMode tests that exact integer mode accepts prefixed literals
+func TestRadixLiteralsIntegerMode(t *testing.T) {
+	result, err := calculator.EvaluateInt("0xFFFFFFFFFFFFFFFFFF + 0b1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.String() != "4722366482869645213696" {
+		t.Errorf("Expected 4722366482869645213696, got %s", result)
+	}
+
+	if _, err := calculator.EvaluateInt("0xG1"); err == nil {
+		t.Error("Expected error for malformed literal in integer mode")
+	}
+}
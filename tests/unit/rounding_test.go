@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestRoundingFunctions tests round, floor, ceil and trunc
+func TestRoundingFunctions(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   float64
+	}{
+		{"round(2.5)", 3},
+		{"round(2.4)", 2},
+		{"round(-2.5)", -3},
+		{"floor(1.5)", 1},
+		{"floor(-1.5)", -2},
+		{"ceil(1.2)", 2},
+		{"ceil(-1.5)", -1},
+		{"trunc(1.9)", 1},
+		{"trunc(-1.9)", -1},
+		{"round(-0.4)", 0},
+		{"floor(7)", 7},
+
+		// Decimal places
+		{"round(3.14159, 2)", 3.14},
+		{"round(3.14159, 0)", 3},
+		{"round(2.675, 2)", 2.68},
+		{"round(1.005, 2)", 1.01},
+		{"round(-1.005, 2)", -1.01},
+		{"floor(0.29, 2)", 0.29},
+		{"floor(-3.14159, 3)", -3.142},
+		{"ceil(3.14159, 3)", 3.142},
+		{"ceil(0.1 + 0.2, 1)", 0.4},
+		{"trunc(-3.14159, 2)", -3.14},
+		{"round(1234.5678, -2)", 1200},
+		{"floor(1999, -3)", 1000},
+		{"round(0.1 + 0.2, 10)", 0.3},
+		{"round(2 / 3, 4) * 2", 1.3334},
+	}
+
+	for _, tc := range testCases {
+		result, err := calculator.Evaluate(tc.expression)
+		if err != nil {
+			t.Errorf("Unexpected error for expression '%s': %v", tc.expression, err)
+			continue
+		}
+		if result != tc.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", tc.expression, tc.expected, result)
+		}
+	}
+}
+
+// TestRoundingFunctionErrors tests invalid decimal places and argument counts
+func TestRoundingFunctionErrors(t *testing.T) {
+	testCases := []struct {
+		expression string
+		message    string
+	}{
+		{"round(3.14, 1.5)", "decimal places must be an integer"},
+		{"round(3.14, 1000)", "decimal places must be an integer"},
+		{"floor(1, 2, 3)", "function floor takes 1 to 2 arguments, got 3"},
+		{"ceil()", "missing argument for function ceil"},
+	}
+
+	for _, tc := range testCases {
+		_, err := calculator.Evaluate(tc.expression)
+		if err == nil {
+			t.Errorf("Expected error for expression '%s'", tc.expression)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("For expression '%s': expected error %q, got %q", tc.expression, tc.message, err.Error())
+		}
+	}
+}
+
+// TestRoundingFunctionsRPN tests that the optional argument count is kept in postfix
+func TestRoundingFunctionsRPN(t *testing.T) {
+	rpn, err := calculator.ToRPN("round(3.14159, 2) * floor(1.5)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "3.14159 2 round:2 1.5 floor:1 *"
+	if strings.Join(rpn, " ") != expected {
+		t.Errorf("Expected %q, got %q", expected, strings.Join(rpn, " "))
+	}
+
+	result, err := calculator.EvaluateRPN(expected)
+	if err != nil || result != 3.14 {
+		t.Errorf("Expected 3.14, got %v (error: %v)", result, err)
+	}
+}
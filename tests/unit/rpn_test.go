@@ -0,0 +1,143 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestToRPN tests conversion of infix expressions to reverse Polish notation
+func TestToRPN(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{"Precedence", "2 + 3 * 4", "2 3 4 * +"},
+		{"Single number", "42", "42"},
+		{"Parentheses override precedence", "(2 + 3) * 4", "2 3 + 4 *"},
+		{"Left associative subtraction", "10 - 4 - 3", "10 4 - 3 -"},
+		{"Left associative division", "20 / 2 // 3", "20 2 / 3 //"},
+		{"Right operand grouped", "10 - (4 - 3)", "10 4 3 - -"},
+		{"Mixed precedence", "2 * 3 + 4 * 5", "2 3 * 4 5 * +"},
+		{"Negative numbers", "-2 * -3", "-2 -3 *"},
+		{"Power binds tighter than multiplication", "2 * 3 ^ 2", "2 3 2 ^ *"},
+		{"Right associative power", "2 ^ 3 ^ 2", "2 3 2 ^ ^"},
+		{"Grouped power", "(2 ^ 3) ^ 2", "2 3 ^ 2 ^"},
+		{"Function call", "sqrt(9 + 7) * 2", "9 7 + sqrt 2 *"},
+		{"Constant", "2 * pi", "2 pi *"},
+		{"Nested parentheses", "((1 + 2) * (3 + 4)) / 7", "1 2 + 3 4 + * 7 /"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rpn, err := calculator.ToRPN(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error for expression '%s': %v", tt.expression, err)
+			}
+			if actual := strings.Join(rpn, " "); actual != tt.expected {
+				t.Errorf("For expression '%s': expected %q, got %q", tt.expression, tt.expected, actual)
+			}
+		})
+	}
+
+	t.Run("Token slice", func(t *testing.T) {
+		rpn, err := calculator.ToRPN("2 + 3 * 4")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := []string{"2", "3", "4", "*", "+"}
+		if len(rpn) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, rpn)
+		}
+		for i := range expected {
+			if rpn[i] != expected[i] {
+				t.Errorf("Expected %v, got %v", expected, rpn)
+				break
+			}
+		}
+	})
+}
+
+// TestToRPNErrors tests that invalid expressions are rejected
+func TestToRPNErrors(t *testing.T) {
+	for _, expression := range []string{"", "2 +", "(2 + 3", "2 + 3)", "2 $ 3", "1.2.3 + 1"} {
+		if _, err := calculator.ToRPN(expression); err == nil {
+			t.Errorf("Expected error for expression '%s', got nil", expression)
+		}
+	}
+}
+
+// TestEvaluateRPN tests evaluation of postfix expressions
+func TestEvaluateRPN(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+	}{
+		{"Precedence example", "2 3 4 * +", 14},
+		{"Single number", "42", 42},
+		{"Subtraction order", "10 4 -", 6},
+		{"Division order", "20 4 /", 5},
+		{"Floor division", "7 2 //", 3},
+		{"Negative operands", "-2 -3 *", 6},
+		{"Extra whitespace", "  1   2 +\t3 * ", 9},
+		{"Function pops one operand", "9 7 + sqrt 2 *", 8},
+		{"Constant operand", "pi pi -", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calculator.EvaluateRPN(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error for expression '%s': %v", tt.expression, err)
+			}
+			if result != tt.expected {
+				t.Errorf("For expression '%s': expected %v, got %v", tt.expression, tt.expected, result)
+			}
+		})
+	}
+
+	t.Run("Round trip through ToRPN", func(t *testing.T) {
+		rpn, err := calculator.ToRPN("2 * (3 + 4) - 5 / 2")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		result, err := calculator.EvaluateRPN(strings.Join(rpn, " "))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result != 11.5 {
+			t.Errorf("Expected 11.5, got %v", result)
+		}
+	})
+}
+
+// TestEvaluateRPNErrors tests stack errors and invalid tokens in postfix input
+func TestEvaluateRPNErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		errorText  string
+	}{
+		{"Stack underflow", "2 +", "stack underflow"},
+		{"Operator first", "+ 2 3", "stack underflow"},
+		{"Function underflow", "sqrt", "stack underflow"},
+		{"Trailing operand", "2 3 4 +", "operands left on the stack"},
+		{"Division by zero", "1 0 /", "division by zero"},
+		{"Invalid token", "2 x +", "invalid token"},
+		{"Empty", "   ", "empty expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calculator.EvaluateRPN(tt.expression)
+			if err == nil {
+				t.Fatalf("Expected error for expression '%s', got nil", tt.expression)
+			}
+			if !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorText, err.Error())
+			}
+		})
+	}
+}
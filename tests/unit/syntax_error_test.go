@@ -0,0 +1,60 @@
+package unit
+
+import (
+	"errors"
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"testing"
+)
+
+// TestSyntaxErrorPosition tests that malformed input reports the offending position
+func TestSyntaxErrorPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		position   int
+		offending  string
+		message    string
+	}{
+		{"Unmatched opening parenthesis", "(2 + 3", 0, "(", "mismatched parentheses"},
+		{"Inner unmatched parenthesis", "(1 + (2 * 3)", 0, "(", "mismatched parentheses"},
+		{"Unmatched closing parenthesis", "2 + 3)", 5, ")", "mismatched parentheses"},
+		{"Doubled operator", "2 ++ 3", 3, "+", "invalid expression"},
+		{"Trailing operator", "2 +", 2, "+", "invalid expression"},
+		{"Invalid character", "2 @ 3", 2, "@", "invalid character: @"},
+		{"Invalid number", "1.2.3 + 1", 0, "1.2.3", "invalid token: 1.2.3"},
+		{"Missing operator", "2 3", 2, "3", "invalid expression"},
+		{"Missing function argument", "sqrt()", 5, ")", "missing argument for function sqrt"},
+		{"Stray assignment", "1 + 2 = 3", 6, "=", "invalid token: ="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calculator.Evaluate(tt.expression)
+
+			var syntaxErr *calculator.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected *SyntaxError for expression '%s', got %T: %v", tt.expression, err, err)
+			}
+			if syntaxErr.Position != tt.position {
+				t.Errorf("Expected position %d, got %d", tt.position, syntaxErr.Position)
+			}
+			if syntaxErr.Offending != tt.offending {
+				t.Errorf("Expected offending %q, got %q", tt.offending, syntaxErr.Offending)
+			}
+			if syntaxErr.Error() != tt.message {
+				t.Errorf("Expected message %q, got %q", tt.message, syntaxErr.Error())
+			}
+		})
+	}
+}
+
+// TestNonSyntaxErrors tests that evaluation errors are not reported as syntax errors
+func TestNonSyntaxErrors(t *testing.T) {
+	for _, expression := range []string{"1 / 0", "5 % 0"} {
+		_, err := calculator.Evaluate(expression)
+		var syntaxErr *calculator.SyntaxError
+		if err == nil || errors.As(err, &syntaxErr) {
+			t.Errorf("Expected a non-syntax error for expression '%s', got %v", expression, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestTokenizePreserveTrivia tests that trivia tokens rebuild the input byte-for-byte
+func TestTokenizePreserveTrivia(t *testing.T) {
+	expressions := []string{
+		"2 + 3",
+		"  2+3  ",
+		"(1 + 2)\t*\n3",
+		"10 //  3",
+		"-5 + -3",
+		"3 - - 5",
+		"sqrt( 16 ) + log10",
+		"",
+		"   ",
+	}
+
+	for _, expression := range expressions {
+		t.Run(expression, func(t *testing.T) {
+			tokens, err := calculator.TokenizeWithOptions(expression, calculator.TokenizeOptions{PreserveTrivia: true})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var rebuilt strings.Builder
+			for _, token := range tokens {
+				if expression[token.Pos:token.Pos+len(token.Value)] != token.Value {
+					t.Errorf("Token %q does not match source at offset %d", token.Value, token.Pos)
+				}
+				rebuilt.WriteString(token.Value)
+			}
+			if rebuilt.String() != expression {
+				t.Errorf("Expected %q, rebuilt %q", expression, rebuilt.String())
+			}
+		})
+	}
+}
+
+// TestTokenize tests the default token stream used for evaluation
+func TestTokenize(t *testing.T) {
+	tokens, err := calculator.Tokenize(" (2 + -3) // 4 ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []calculator.Token{
+		{Kind: calculator.TokenLeftParen, Value: "(", Pos: 1},
+		{Kind: calculator.TokenNumber, Value: "2", Pos: 2},
+		{Kind: calculator.TokenOperator, Value: "+", Pos: 4},
+		{Kind: calculator.TokenNumber, Value: "-3", Pos: 6},
+		{Kind: calculator.TokenRightParen, Value: ")", Pos: 8},
+		{Kind: calculator.TokenOperator, Value: "//", Pos: 10},
+		{Kind: calculator.TokenNumber, Value: "4", Pos: 13},
+	}
+
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expected), len(tokens), tokens)
+	}
+	for i := range expected {
+		if tokens[i] != expected[i] {
+			t.Errorf("Token %d: expected %+v, got %+v", i, expected[i], tokens[i])
+		}
+	}
+
+	tokens, err = calculator.Tokenize("-sqrt(x2)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	kinds := []calculator.TokenKind{calculator.TokenOperator, calculator.TokenIdent, calculator.TokenLeftParen, calculator.TokenIdent, calculator.TokenRightParen}
+	if len(tokens) != len(kinds) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(kinds), len(tokens), tokens)
+	}
+	for i, kind := range kinds {
+		if tokens[i].Kind != kind {
+			t.Errorf("Token %d (%q): expected kind %v, got %v", i, tokens[i].Value, kind, tokens[i].Kind)
+		}
+	}
+
+	if _, err := calculator.Tokenize("2 $ 3"); err == nil {
+		t.Error("Expected error for invalid character")
+	}
+}
@@ -0,0 +1,190 @@
+package unit
+
+import (
+	"github.com/dmisiuk/acousticalc/pkg/calculator"
+	"strings"
+	"testing"
+)
+
+// TestCalculatorVariables tests assigning and reusing variables across calls
+func TestCalculatorVariables(t *testing.T) {
+	calc := calculator.New()
+
+	steps := []struct {
+		expression string
+		expected   float64
+	}{
+		{"x = 3 + 4", 7},
+		{"x", 7},
+		{"x * 2", 14},
+		{"y = x ^ 2", 49},
+		{"y - x", 42},
+		{"x = x + 1", 8},
+		{"x", 8},
+		{"radius = 2", 2},
+		{"area = pi * radius ^ 2", 12.566370614359172},
+		{"sqrt(y)", 7},
+		{"z_1 = 50%", 0.5},
+	}
+
+	for _, step := range steps {
+		result, err := calc.Eval(step.expression)
+		if err != nil {
+			t.Fatalf("Unexpected error for expression '%s': %v", step.expression, err)
+		}
+		if result != step.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", step.expression, step.expected, result)
+		}
+	}
+
+	if val, ok := calc.Variable("y"); !ok || val != 49 {
+		t.Errorf("Expected y to be 49, got %v (defined: %v)", val, ok)
+	}
+	if _, ok := calc.Variable("undefined"); ok {
+		t.Error("Expected undefined variable to be missing")
+	}
+}
+
+// TestCalculatorVariableErrors tests invalid assignments and references
+func TestCalculatorVariableErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		errorText  string
+	}{
+		{"Reserved constant", "pi = 3", "cannot assign to reserved name: pi"},
+		{"Reserved function", "sqrt = 4", "cannot assign to reserved name: sqrt"},
+		{"Undefined variable", "w + 1", "unknown identifier: w"},
+		{"Missing value", "x =", "invalid expression"},
+		{"Assignment to number", "3 = 4", "invalid token: ="},
+		{"Assignment inside expression", "1 + 2 = 3", "invalid token: ="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := calculator.New()
+			_, err := calc.Eval(tt.expression)
+			if err == nil {
+				t.Fatalf("Expected error for expression '%s', got nil", tt.expression)
+			}
+			if !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Expected error containing %q, got %q", tt.errorText, err.Error())
+			}
+		})
+	}
+
+	t.Run("Failed assignment keeps old value", func(t *testing.T) {
+		calc := calculator.New()
+		if _, err := calc.Eval("x = 5"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := calc.Eval("x = 1 / 0"); err == nil {
+			t.Fatal("Expected division by zero error")
+		}
+		if val, _ := calc.Variable("x"); val != 5 {
+			t.Errorf("Expected x to stay 5, got %v", val)
+		}
+	})
+
+	t.Run("Package-level Evaluate has no variables", func(t *testing.T) {
+		if _, err := calculator.Evaluate("x = 1"); err == nil {
+			t.Error("Expected Evaluate to reject assignment")
+		}
+	})
+}
+
+// TestCalculatorAns tests chaining calculations through the previous result
+func TestCalculatorAns(t *testing.T) {
+	calc := calculator.New()
+
+	if _, err := calc.Eval("ans * 10"); err == nil || err.Error() != "no previous result" {
+		t.Fatalf("Expected no previous result error, got %v", err)
+	}
+
+	steps := []struct {
+		expression string
+		expected   float64
+	}{
+		{"2+2", 4},
+		{"ans * 10", 40},
+		{"ans + ans", 80},
+		{"x = ans / 8", 10},
+		{"ans", 10},
+		{"sqrt(ans * 10)", 10},
+	}
+
+	for _, step := range steps {
+		result, err := calc.Eval(step.expression)
+		if err != nil {
+			t.Fatalf("Unexpected error for expression '%s': %v", step.expression, err)
+		}
+		if result != step.expected {
+			t.Errorf("For expression '%s': expected %v, got %v", step.expression, step.expected, result)
+		}
+	}
+
+	t.Run("Failed evaluation keeps previous result", func(t *testing.T) {
+		if _, err := calc.Eval("ans / 0"); err == nil {
+			t.Fatal("Expected division by zero error")
+		}
+		if result, err := calc.Eval("ans"); err != nil || result != 10 {
+			t.Errorf("Expected ans to stay 10, got %v (%v)", result, err)
+		}
+	})
+
+	t.Run("ans is reserved", func(t *testing.T) {
+		if _, err := calc.Eval("ans = 3"); err == nil || !strings.Contains(err.Error(), "reserved name: ans") {
+			t.Errorf("Expected reserved name error, got %v", err)
+		}
+	})
+}
+
+// TestCalculatorClone tests that a clone and its original do not share state
+func TestCalculatorClone(t *testing.T) {
+	base := calculator.New()
+	if err := base.RegisterFunction("double", func(x float64) (float64, error) { return 2 * x, nil }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	base.SetAngleMode(calculator.Degrees)
+	assertCalcResult(t, base, "rate = 0.2", 0.2)
+	assertCalcResult(t, base, "6 * 7", 42)
+
+	clone := base.Clone()
+
+	t.Run("Clone starts with the original state", func(t *testing.T) {
+		assertCalcResult(t, clone, "ans", 42)
+		assertCalcResult(t, clone, "double(rate)", 0.4)
+		assertCalcResult(t, clone, "sin(30)", 0.5)
+	})
+
+	t.Run("Assigning in the clone leaves the original unchanged", func(t *testing.T) {
+		assertCalcResult(t, clone, "rate = 0.5", 0.5)
+		assertCalcResult(t, clone, "extra = 1", 1)
+
+		if val, _ := base.Variable("rate"); val != 0.2 {
+			t.Errorf("Expected original rate 0.2, got %v", val)
+		}
+		if _, ok := base.Variable("extra"); ok {
+			t.Error("Expected extra to be undefined in the original")
+		}
+		assertCalcResult(t, base, "ans", 42)
+	})
+
+	t.Run("Changing the original leaves the clone unchanged", func(t *testing.T) {
+		assertCalcResult(t, base, "rate = 0.1", 0.1)
+		if err := base.RegisterFunction("triple", func(x float64) (float64, error) { return 3 * x, nil }); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		base.SetAngleMode(calculator.Radians)
+
+		if val, _ := clone.Variable("rate"); val != 0.5 {
+			t.Errorf("Expected clone rate 0.5, got %v", val)
+		}
+		if _, err := clone.Eval("triple(1)"); err == nil {
+			t.Error("Expected triple to be unknown in the clone")
+		}
+		if clone.AngleMode() != calculator.Degrees {
+			t.Errorf("Expected clone to stay in degrees, got %v", clone.AngleMode())
+		}
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
@@ -29,6 +31,10 @@ type ArtifactGenerator struct {
 	ctx                context.Context
 	cancel             context.CancelFunc
 	performanceMonitor *PerformanceMonitor
+
+	// IncludeSystemSnapshot attaches CPU, memory, OS version and filtered
+	// environment details to the metadata files
+	IncludeSystemSnapshot bool
 }
 
 // ArtifactSummary provides a summary of generated artifacts
@@ -51,6 +57,8 @@ type ArtifactMetadata struct {
 	Screenshots  int       `json:"screenshot_count"`
 	Reports      int       `json:"report_count"`
 	TotalSize    int64     `json:"total_size_bytes"`
+
+	System *SystemSnapshot `json:"system,omitempty"`
 }
 
 // ScreenshotInfo contains information about individual screenshots
@@ -60,6 +68,8 @@ type ScreenshotInfo struct {
 	Timestamp  time.Time         `json:"timestamp"`
 	Size       int64             `json:"size_bytes"`
 	Dimensions string            `json:"dimensions"`
+	Expression string            `json:"expression,omitempty"` // Expression on screen when captured
+	Result     string            `json:"result,omitempty"`     // Result (or error) of that expression
 	Metadata   map[string]string `json:"metadata"`
 }
 
@@ -172,7 +182,11 @@ func (ag *ArtifactGenerator) createDirectoryStructure() error {
 func (ag *ArtifactGenerator) generateEnhancedVisualReport(logger *VisualTestLogger) error {
 	reportPath := filepath.Join(ag.OutputBaseDir, "reports", fmt.Sprintf("%s_comprehensive_report.html", ag.TestName))
 
-	html := ag.generateEnhancedHTMLReport(logger)
+	ag.collectScreenshots(logger)
+	html, err := ag.generateEnhancedHTMLReport(logger)
+	if err != nil {
+		return err
+	}
 
 	if err := os.WriteFile(reportPath, []byte(html), 0644); err != nil {
 		return err
@@ -192,14 +206,18 @@ func (ag *ArtifactGenerator) generateEnhancedVisualReport(logger *VisualTestLogg
 	return nil
 }
 
-// generateEnhancedHTMLReport creates a professional HTML report
-func (ag *ArtifactGenerator) generateEnhancedHTMLReport(logger *VisualTestLogger) string {
-	html := `<!DOCTYPE html>
+// enhancedReportTemplate renders the comprehensive visual report.
+// html/template escapes test names, event types, descriptions, file paths and
+// metadata in both text and attributes.
+var enhancedReportTemplate = template.Must(template.New("enhanced_report").Funcs(template.FuncMap{
+	"reportPath": screenshotReportPath,
+	"caption":    calculationCaption,
+}).Parse(`<!DOCTYPE html>
 <html lang="en">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>AcoustiCalc Visual Testing Report - ` + ag.TestName + `</title>
+    <title>AcoustiCalc Visual Testing Report - {{.TestName}}</title>
     <style>
         * { margin: 0; padding: 0; box-sizing: border-box; }
         body {
@@ -235,6 +253,15 @@ func (ag *ArtifactGenerator) generateEnhancedHTMLReport(logger *VisualTestLogger
             background: rgba(0,0,0,0.2); padding: 15px; border-radius: 8px;
             margin-top: 15px; font-size: 0.9em; font-family: 'Monaco', monospace;
         }
+        .thumbnail-grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(160px, 1fr)); gap: 15px; margin: 40px 0; }
+        .thumbnail { background: rgba(255,255,255,0.1); border-radius: 8px; padding: 10px; text-align: center; }
+        .thumbnail-image { width: 100%; height: 100px; object-fit: cover; border-radius: 4px; }
+        .thumbnail-label { font-size: 0.9em; margin: 5px 0; color: #32cd32; }
+        .thumbnail-calculation { font-size: 0.8em; margin-bottom: 5px; font-family: 'Monaco', monospace; }
+        .copy-path {
+            background: none; border: 1px solid rgba(255,255,255,0.3); border-radius: 4px;
+            color: #ffffff; font-size: 0.8em; padding: 2px 8px; cursor: pointer;
+        }
         .footer { text-align: center; margin-top: 80px; opacity: 0.7; }
     </style>
 </head>
@@ -242,70 +269,144 @@ func (ag *ArtifactGenerator) generateEnhancedHTMLReport(logger *VisualTestLogger
     <div class="container">
         <div class="header">
             <h1>AcoustiCalc Visual Testing</h1>
-            <h2>` + ag.TestName + `</h2>
-            <p>Generated on ` + time.Now().Format("January 2, 2006 at 15:04:05") + `</p>
+            <h2>{{.TestName}}</h2>
+            <p>Generated on {{.Generated.Format "January 2, 2006 at 15:04:05"}}</p>
         </div>
 
         <div class="stats">
             <div class="stat-card">
-                <div class="stat-number">` + fmt.Sprintf("%d", len(logger.Events)) + `</div>
+                <div class="stat-number">{{len .Events}}</div>
                 <div class="stat-label">Visual Events Captured</div>
             </div>
             <div class="stat-card">
-                <div class="stat-number">` + fmt.Sprintf("%d", len(logger.Screenshots)) + `</div>
+                <div class="stat-number">{{.ScreenshotCount}}</div>
                 <div class="stat-label">Screenshots Generated</div>
             </div>
             <div class="stat-card">
-                <div class="stat-number">>95%</div>
+                <div class="stat-number">&gt;95%</div>
                 <div class="stat-label">Coverage Target</div>
             </div>
             <div class="stat-card">
-                <div class="stat-number"><30s</div>
+                <div class="stat-number">&lt;30s</div>
                 <div class="stat-label">CI Time Constraint</div>
             </div>
         </div>
 
-        <div class="events-timeline">
-            <div class="timeline-header">Test Execution Timeline</div>`
+        <div class="thumbnail-grid">
+{{- range .Screenshots}}
+            <div class="thumbnail">
+                <a href="{{reportPath .Filename}}"><img src="{{reportPath .Filename}}" class="thumbnail-image" alt="Screenshot for {{.EventType}}"></a>
+                <div class="thumbnail-label">{{.EventType}}</div>
+                {{- if .Expression}}
+                <div class="thumbnail-calculation">{{caption .Expression .Result}}</div>
+                {{- end}}
+                <button class="copy-path" data-path="{{reportPath .Filename}}" onclick="navigator.clipboard.writeText(this.dataset.path)">Copy path</button>
+            </div>
+{{- end}}
+        </div>
 
-	// Add events to timeline
-	for _, event := range logger.Events {
-		html += `
+        <div class="events-timeline">
+            <div class="timeline-header">Test Execution Timeline</div>
+{{- range .Events}}
             <div class="event-item">
                 <div class="event-meta">
-                    <div class="event-time">` + event.Timestamp.Format("15:04:05.000") + `</div>
-                    <div class="event-type">` + string(event.Type) + `</div>
+                    <div class="event-time">{{.Timestamp.Format "15:04:05.000"}}</div>
+                    <div class="event-type">{{.Type}}</div>
                 </div>
                 <div class="event-content">
-                    <div class="event-description">` + event.Description + `</div>`
-
-		if event.Screenshot != "" {
-			html += `<img src="../screenshots/unit/` + filepath.Base(event.Screenshot) + `" class="screenshot" alt="Screenshot for ` + string(event.Type) + `">`
-		}
-
-		if len(event.Metadata) > 0 {
-			html += `<div class="metadata"><strong>Event Metadata:</strong><br>`
-			for key, value := range event.Metadata {
-				html += fmt.Sprintf("%s: %v<br>", key, value)
-			}
-			html += `</div>`
-		}
-
-		html += `</div></div>`
-	}
-
-	html += `
+                    <div class="event-description">{{.Description}}</div>
+                    {{- if .Screenshot}}
+                    <img src="{{reportPath .Screenshot}}" class="screenshot" alt="Screenshot for {{.Type}}">
+                    {{- end}}
+                    {{- if .Metadata}}
+                    <div class="metadata"><strong>Event Metadata:</strong><br>
+                    {{- range $key, $value := .Metadata}}{{$key}}: {{$value}}<br>{{end -}}
+                    </div>
+                    {{- end}}
+                </div>
+            </div>
+{{- end}}
         </div>
 
         <div class="footer">
             <p>Generated by AcoustiCalc Visual Testing Framework v0.2.2</p>
-            <p>Cross-Platform Visual Evidence & Demo Content Generation</p>
+            <p>Cross-Platform Visual Evidence &amp; Demo Content Generation</p>
         </div>
     </div>
 </body>
-</html>`
+</html>`))
+
+// enhancedReportData is the data rendered by enhancedReportTemplate
+type enhancedReportData struct {
+	TestName        string
+	Generated       time.Time
+	Events          []VisualEvent
+	ScreenshotCount int
+	Screenshots     []ScreenshotInfo
+}
 
-	return html
+// generateEnhancedHTMLReport creates a professional HTML report
+func (ag *ArtifactGenerator) generateEnhancedHTMLReport(logger *VisualTestLogger) (string, error) {
+	data := enhancedReportData{
+		TestName:        ag.TestName,
+		Generated:       time.Now(),
+		Events:          logger.Events,
+		ScreenshotCount: len(logger.Screenshots),
+		Screenshots:     ag.screenshotsSnapshot(),
+	}
+
+	var html strings.Builder
+	if err := enhancedReportTemplate.Execute(&html, data); err != nil {
+		return "", fmt.Errorf("failed to render comprehensive report: %w", err)
+	}
+	return html.String(), nil
+}
+
+// screenshotReportPath returns the path of a screenshot relative to the reports directory
+func screenshotReportPath(screenshot string) string {
+	return "../screenshots/unit/" + filepath.Base(screenshot)
+}
+
+// collectScreenshots records the logger's captured screenshots that are not tracked yet
+func (ag *ArtifactGenerator) collectScreenshots(logger *VisualTestLogger) {
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+
+	known := make(map[string]bool, len(ag.Screenshots))
+	for _, screenshot := range ag.Screenshots {
+		known[filepath.Base(screenshot.Filename)] = true
+	}
+
+	for _, event := range logger.Events {
+		if event.Screenshot == "" || known[filepath.Base(event.Screenshot)] {
+			continue
+		}
+
+		info := ScreenshotInfo{
+			Filename:   filepath.Base(event.Screenshot),
+			EventType:  string(event.Type),
+			Timestamp:  event.Timestamp,
+			Expression: event.Expression,
+			Result:     event.Result,
+			Metadata:   make(map[string]string),
+		}
+		if stat, err := os.Stat(event.Screenshot); err == nil {
+			info.Size = stat.Size()
+		}
+
+		ag.Screenshots = append(ag.Screenshots, info)
+		known[info.Filename] = true
+	}
+}
+
+// screenshotsSnapshot returns a thread-safe copy of the tracked screenshots
+func (ag *ArtifactGenerator) screenshotsSnapshot() []ScreenshotInfo {
+	ag.mu.RLock()
+	defer ag.mu.RUnlock()
+
+	screenshots := make([]ScreenshotInfo, len(ag.Screenshots))
+	copy(screenshots, ag.Screenshots)
+	return screenshots
 }
 
 // generateProfessionalStoryboard creates a demo-quality storyboard
@@ -396,10 +497,10 @@ func (ag *ArtifactGenerator) generateProfessionalStoryboardHTML(logger *VisualTe
                     </div>
                 </div>`,
 				i+1,
-				filepath.Base(event.Screenshot),
-				event.Description,
-				string(event.Type),
-				event.Description,
+				template.HTMLEscapeString(filepath.Base(event.Screenshot)),
+				template.HTMLEscapeString(event.Description),
+				template.HTMLEscapeString(string(event.Type)),
+				template.HTMLEscapeString(event.Description),
 				event.Timestamp.Format("15:04:05"))
 		}
 	}
@@ -436,7 +537,7 @@ func (ag *ArtifactGenerator) generateTimelineHTML(logger *VisualTestLogger) stri
 <p>Test Duration: %v</p>
 <p>Total Events: %d</p>
 </body></html>`,
-		ag.TestName,
+		template.HTMLEscapeString(ag.TestName),
 		time.Since(logger.StartTime),
 		len(logger.Events))
 }
@@ -462,6 +563,10 @@ func (ag *ArtifactGenerator) generateMetadataFiles() error {
 	// Update metadata totals
 	ag.Metadata.Screenshots = len(ag.Screenshots)
 	ag.Metadata.Reports = len(ag.Reports)
+	if ag.IncludeSystemSnapshot {
+		snapshot := CaptureSystemSnapshot()
+		ag.Metadata.System = &snapshot
+	}
 
 	metadataJSON, err := json.MarshalIndent(ag.Metadata, "", "  ")
 	if err != nil {
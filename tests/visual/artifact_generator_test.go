@@ -0,0 +1,174 @@
+package visual
+
+import (
+	"image"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEnhancedReportThumbnailGrid tests the screenshot thumbnail grid in the comprehensive report
+func TestEnhancedReportThumbnailGrid(t *testing.T) {
+	testDir := t.TempDir()
+
+	t.Run("thumbnail_per_screenshot", func(t *testing.T) {
+		generator := NewArtifactGenerator("thumbnail_test", testDir)
+		defer generator.Close()
+
+		screenshots := []ScreenshotInfo{
+			{Filename: "thumbnail_test_start_0.png", EventType: "start", Timestamp: time.Now()},
+			{Filename: "thumbnail_test_pass_1.png", EventType: "pass", Timestamp: time.Now()},
+			{Filename: "thumbnail_test_complete_2.png", EventType: "complete", Timestamp: time.Now()},
+		}
+		for _, screenshot := range screenshots {
+			generator.AddScreenshot(screenshot)
+		}
+
+		logger := NewVisualTestLogger("thumbnail_test", testDir)
+		html, err := generator.generateEnhancedHTMLReport(logger)
+		if err != nil {
+			t.Fatalf("generateEnhancedHTMLReport failed: %v", err)
+		}
+
+		if count := strings.Count(html, `class="thumbnail-image"`); count != len(screenshots) {
+			t.Errorf("Expected %d thumbnails, got %d", len(screenshots), count)
+		}
+
+		for _, screenshot := range screenshots {
+			path := "../screenshots/unit/" + screenshot.Filename
+			if !strings.Contains(html, `<img src="`+path+`" class="thumbnail-image"`) {
+				t.Errorf("Missing thumbnail image for %s", path)
+			}
+			if !strings.Contains(html, `<a href="`+path+`">`) {
+				t.Errorf("Thumbnail does not link to full screenshot %s", path)
+			}
+			if !strings.Contains(html, `data-path="`+path+`"`) {
+				t.Errorf("Missing copy-path control for %s", path)
+			}
+			if !strings.Contains(html, `<div class="thumbnail-label">`+screenshot.EventType+`</div>`) {
+				t.Errorf("Missing event label %s", screenshot.EventType)
+			}
+		}
+	})
+
+	t.Run("collects_logger_screenshots", func(t *testing.T) {
+		generator := NewArtifactGenerator("collect_test", testDir)
+		defer generator.Close()
+
+		logger := NewVisualTestLogger("collect_test", testDir)
+		logger.Events = append(logger.Events,
+			VisualEvent{Type: EventTestStart, Timestamp: time.Now(), Screenshot: filepath.Join(testDir, "collect_test_start_0.png")},
+			VisualEvent{Type: EventTestProcess, Timestamp: time.Now()},
+		)
+
+		generator.AddScreenshot(ScreenshotInfo{Filename: "collect_test_start_0.png", EventType: "start"})
+		generator.collectScreenshots(logger)
+		generator.collectScreenshots(logger)
+
+		if len(generator.Screenshots) != 1 {
+			t.Errorf("Expected screenshots to be tracked once, got %d", len(generator.Screenshots))
+		}
+	})
+}
+
+// TestScreenshotCalculationMetadata tests that screenshots carry the expression and result being evaluated
+func TestScreenshotCalculationMetadata(t *testing.T) {
+	testDir := t.TempDir()
+
+	logger := NewVisualTestLogger("calculation_test", testDir)
+	logger.SetScreenshotEngine(&MockScreenshotEngine{Image: image.NewRGBA(image.Rect(0, 0, 4, 4))})
+	logger.LogEvent(EventTestProcess, "Evaluating expression", map[string]interface{}{
+		"expression": "2 + 3",
+		"result":     5.0,
+	})
+	logger.LogEvent(EventTestFail, "Invalid expression", map[string]interface{}{
+		"expression": "2 +",
+		"error":      "invalid expression",
+	})
+	logger.LogEvent(EventTestStart, "No calculation", nil)
+	logger.LogEvent(EventTestProcess, "Shifting", map[string]interface{}{
+		"expression": "1 << 2",
+		"result":     4,
+	})
+
+	events := logger.Events
+	if events[0].Expression != "2 + 3" || events[0].Result != "5" {
+		t.Errorf("Expected expression %q with result %q, got %q and %q", "2 + 3", "5", events[0].Expression, events[0].Result)
+	}
+	if events[1].Expression != "2 +" || events[1].Result != "Error: invalid expression" {
+		t.Errorf("Expected failed expression to carry its error, got %q and %q", events[1].Expression, events[1].Result)
+	}
+	if events[2].Expression != "" || events[2].Result != "" {
+		t.Errorf("Expected no calculation for plain event, got %q and %q", events[2].Expression, events[2].Result)
+	}
+
+	// Screenshots collected from the logger keep the calculation for captions
+	generator := NewArtifactGenerator("calculation_test", testDir)
+	defer generator.Close()
+	generator.collectScreenshots(logger)
+
+	screenshots := generator.screenshotsSnapshot()
+	if len(screenshots) != 4 {
+		t.Fatalf("Expected 4 screenshots, got %d", len(screenshots))
+	}
+	if screenshots[0].Expression != "2 + 3" || screenshots[0].Result != "5" {
+		t.Errorf("Screenshot metadata lost calculation: %+v", screenshots[0])
+	}
+	if screenshots[1].Result != "Error: invalid expression" || screenshots[2].Expression != "" {
+		t.Errorf("Unexpected screenshot calculations: %+v", screenshots[1:3])
+	}
+
+	html, err := generator.generateEnhancedHTMLReport(logger)
+	if err != nil {
+		t.Fatalf("generateEnhancedHTMLReport failed: %v", err)
+	}
+	// html/template writes + as the equivalent character reference &#43;
+	if !strings.Contains(html, `<div class="thumbnail-calculation">2 &#43; 3 = 5</div>`) {
+		t.Error("Expected thumbnail caption with the calculation")
+	}
+	if !strings.Contains(html, `<div class="thumbnail-calculation">1 &lt;&lt; 2 = 4</div>`) {
+		t.Error("Expected the shift in the caption to be escaped")
+	}
+	if strings.Contains(html, "1 << 2") {
+		t.Error("Report contains an unescaped caption")
+	}
+}
+
+// TestEnhancedReportEscaping tests that every interpolated field of the
+// comprehensive report is escaped, in text and in attributes
+func TestEnhancedReportEscaping(t *testing.T) {
+	testDir := t.TempDir()
+	generator := NewArtifactGenerator("escape<b>test", testDir)
+	defer generator.Close()
+	generator.AddScreenshot(ScreenshotInfo{Filename: `shot"onerror="alert(1).png`, EventType: "<script>alert(2)</script>", Timestamp: time.Now()})
+
+	logger := NewVisualTestLogger("escape_test", testDir)
+	logger.Events = append(logger.Events, VisualEvent{
+		Timestamp:   time.Now(),
+		Type:        VisualTestEvent("<i>type</i>"),
+		Description: "<script>alert(3)</script>",
+		Screenshot:  `x"><script>alert(4)</script>.png`,
+		Metadata:    map[string]interface{}{"<k>": "<v>"},
+	})
+
+	html, err := generator.generateEnhancedHTMLReport(logger)
+	if err != nil {
+		t.Fatalf("generateEnhancedHTMLReport failed: %v", err)
+	}
+	for _, raw := range []string{"<script>", "<b>", "<i>", "<k>", "<v>", `"onerror="`} {
+		if strings.Contains(html, raw) {
+			t.Errorf("Report contains unescaped %q", raw)
+		}
+	}
+	for _, escaped := range []string{
+		"escape&lt;b&gt;test",
+		`<div class="thumbnail-label">&lt;script&gt;alert(2)&lt;/script&gt;</div>`,
+		"&lt;script&gt;alert(3)&lt;/script&gt;",
+		"&lt;k&gt;: &lt;v&gt;<br>",
+	} {
+		if !strings.Contains(html, escaped) {
+			t.Errorf("Expected report to contain %q", escaped)
+		}
+	}
+}
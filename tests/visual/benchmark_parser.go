@@ -0,0 +1,86 @@
+package visual
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// BenchResult holds one result line of `go test -bench` output
+type BenchResult struct {
+	Name        string  `json:"name"`
+	N           int     `json:"n"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`  // Only set with -benchmem
+	AllocsPerOp int64   `json:"allocs_per_op"` // Only set with -benchmem
+	HasMemStats bool    `json:"has_mem_stats"`
+}
+
+// ParseBenchmarks reads `go test -bench` output and returns its benchmark results.
+// Lines that are not benchmark results (PASS, ok, goos, log output or malformed
+// lines) are skipped.
+func ParseBenchmarks(r io.Reader) ([]BenchResult, error) {
+	var results []BenchResult
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if result, ok := parseBenchmarkLine(scanner.Text()); ok {
+			results = append(results, result)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read benchmark output: %w", err)
+	}
+
+	return results, nil
+}
+
+// parseBenchmarkLine parses a single result line such as
+// "BenchmarkEvaluate-8   1000000   1052 ns/op   160 B/op   1 allocs/op"
+func parseBenchmarkLine(line string) (BenchResult, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return BenchResult{}, false
+	}
+
+	n, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return BenchResult{}, false
+	}
+
+	result := BenchResult{Name: fields[0], N: n}
+	hasNsPerOp := false
+
+	// The remaining fields are value/unit pairs
+	for i := 2; i+1 < len(fields); i += 2 {
+		value, unit := fields[i], fields[i+1]
+		switch unit {
+		case "ns/op":
+			nsPerOp, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return BenchResult{}, false
+			}
+			result.NsPerOp = nsPerOp
+			hasNsPerOp = true
+		case "B/op":
+			bytesPerOp, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return BenchResult{}, false
+			}
+			result.BytesPerOp = bytesPerOp
+			result.HasMemStats = true
+		case "allocs/op":
+			allocsPerOp, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return BenchResult{}, false
+			}
+			result.AllocsPerOp = allocsPerOp
+			result.HasMemStats = true
+		}
+	}
+
+	return result, hasNsPerOp
+}
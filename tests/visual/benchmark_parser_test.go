@@ -0,0 +1,58 @@
+package visual
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseBenchmarks tests parsing `go test -bench -benchmem` output
+func TestParseBenchmarks(t *testing.T) {
+	output := `goos: linux
+goarch: amd64
+pkg: github.com/dmisiuk/acousticalc/tests/unit
+cpu: Intel(R) Xeon(R) Processor
+BenchmarkEvaluateAllocs/Simple-8         	 8135278	       144.3 ns/op	      96 B/op	       1 allocs/op
+BenchmarkEvaluateAllocs/Nested-8         	 2262756	       576.2 ns/op	     928 B/op	       2 allocs/op
+BenchmarkParallel-8                      	20000000	        61 ns/op
+BenchmarkThroughput-8                    	  500000	      2400 ns/op	  41.67 MB/s	     512 B/op	       4 allocs/op
+BenchmarkMalformedCount-8                	     abc	       144.3 ns/op
+BenchmarkMalformedValue-8                	    1000	       fast ns/op
+BenchmarkNoTiming-8
+    benchmark_test.go:42: some log output
+--- FAIL: BenchmarkBroken
+PASS
+ok  	github.com/dmisiuk/acousticalc/tests/unit	10.553s
+`
+
+	results, err := ParseBenchmarks(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParseBenchmarks failed: %v", err)
+	}
+
+	expected := []BenchResult{
+		{Name: "BenchmarkEvaluateAllocs/Simple-8", N: 8135278, NsPerOp: 144.3, BytesPerOp: 96, AllocsPerOp: 1, HasMemStats: true},
+		{Name: "BenchmarkEvaluateAllocs/Nested-8", N: 2262756, NsPerOp: 576.2, BytesPerOp: 928, AllocsPerOp: 2, HasMemStats: true},
+		{Name: "BenchmarkParallel-8", N: 20000000, NsPerOp: 61},
+		{Name: "BenchmarkThroughput-8", N: 500000, NsPerOp: 2400, BytesPerOp: 512, AllocsPerOp: 4, HasMemStats: true},
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d: %+v", len(expected), len(results), results)
+	}
+	for i := range expected {
+		if results[i] != expected[i] {
+			t.Errorf("Result %d: expected %+v, got %+v", i, expected[i], results[i])
+		}
+	}
+}
+
+// TestParseBenchmarksEmpty tests output without any benchmark results
+func TestParseBenchmarksEmpty(t *testing.T) {
+	results, err := ParseBenchmarks(strings.NewReader("PASS\nok  \tpkg\t0.1s\n"))
+	if err != nil {
+		t.Fatalf("ParseBenchmarks failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results, got %+v", results)
+	}
+}
@@ -0,0 +1,134 @@
+package visual
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// realScreenshotBenchEnv enables benchmarks that capture the real screen
+const realScreenshotBenchEnv = "ACOUSTICALC_REAL_SCREENSHOT_BENCH"
+
+// newMockCapture returns a capture that encodes a blank screen-sized image
+// without touching the display
+func newMockCapture(testName, outputDir string, encoder ImageEncoder) *ScreenshotCapture {
+	capture := NewScreenshotCapture(testName, outputDir)
+	capture.Encoder = encoder
+	capture.SetEngine(&MockScreenshotEngine{Image: image.NewRGBA(image.Rect(0, 0, 1920, 1080))})
+	return capture
+}
+
+// BenchmarkCaptureScreenEngines compares CaptureScreen cost with the mock and real engines
+func BenchmarkCaptureScreenEngines(b *testing.B) {
+	for _, encoder := range []ImageEncoder{EncoderImaging, EncoderStdlib} {
+		b.Run("mock_"+string(encoder), func(b *testing.B) {
+			capture := newMockCapture("bench_mock", b.TempDir(), encoder)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := capture.CaptureScreen("benchmark"); err != nil {
+					b.Fatalf("Mock capture failed: %v", err)
+				}
+			}
+		})
+	}
+
+	b.Run("real", func(b *testing.B) {
+		if os.Getenv(realScreenshotBenchEnv) == "" {
+			b.Skipf("Set %s=1 to benchmark the real screenshot engine", realScreenshotBenchEnv)
+		}
+		capture := NewScreenshotCapture("bench_real", b.TempDir())
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := capture.CaptureScreen("benchmark"); err != nil {
+				b.Skipf("Screenshot capture not available: %v", err)
+			}
+		}
+	})
+}
+
+// BenchmarkReportGeneration benchmarks generating the visual and artifact reports
+func BenchmarkReportGeneration(b *testing.B) {
+	outputDir := b.TempDir()
+	logger := NewVisualTestLogger("bench_report", outputDir)
+	generator := NewArtifactGenerator("bench_report", outputDir)
+	defer generator.Close()
+
+	for i := 0; i < 50; i++ {
+		logger.Events = append(logger.Events, VisualEvent{
+			Type:        EventTestProcess,
+			Timestamp:   time.Now(),
+			Description: fmt.Sprintf("Step %d", i),
+			Expression:  fmt.Sprintf("%d + 1", i),
+			Result:      fmt.Sprint(i + 1),
+		})
+		generator.AddScreenshot(ScreenshotInfo{Filename: ScreenshotFilename("bench_report", "process", i), EventType: "process"})
+	}
+
+	b.Run("visual_report", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = logger.generateHTMLReport()
+		}
+	})
+
+	b.Run("enhanced_report", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = generator.generateEnhancedHTMLReport(logger)
+		}
+	})
+}
+
+// TestMockCaptureWithinThreshold tests that a mock capture is far below the screenshot threshold
+func TestMockCaptureWithinThreshold(t *testing.T) {
+	capture := newMockCapture("threshold_test", t.TempDir(), EncoderStdlib)
+	monitor := NewPerformanceMonitor(t.Context())
+	defer monitor.Stop()
+
+	for i := 0; i < 3; i++ {
+		err := monitor.TrackOperation("screenshot_capture", func() error {
+			_, err := capture.CaptureScreen("threshold")
+			return err
+		})
+		if err != nil {
+			t.Fatalf("Mock capture failed: %v", err)
+		}
+	}
+
+	if violations := monitor.CheckThresholds(); len(violations) > 0 {
+		t.Errorf("Unexpected threshold violations: %v", violations)
+	}
+
+	// Well under means at least an order of magnitude of headroom
+	metric := monitor.GetMetrics()["screenshot_capture"]
+	if limit := 5 * time.Second / 10; metric.MaxTime > limit {
+		t.Errorf("Mock capture took %v, expected under %v", metric.MaxTime, limit)
+	}
+}
+
+// TestRecordBenchmarks tests feeding parsed benchmark timings into a performance report
+func TestRecordBenchmarks(t *testing.T) {
+	output := `BenchmarkCaptureScreenEngines/mock_stdlib-8   	     100	  12500000 ns/op
+BenchmarkReportGeneration/visual_report-8     	    5000	    250000 ns/op
+`
+	results, err := ParseBenchmarks(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("ParseBenchmarks failed: %v", err)
+	}
+
+	monitor := NewCIPerformanceMonitor()
+	monitor.RecordBenchmarks(results)
+
+	expected := map[string]float64{
+		"bench_CaptureScreenEngines_mock_stdlib_8_ms": 12.5,
+		"bench_ReportGeneration_visual_report_8_ms":   0.25,
+	}
+	for key, want := range expected {
+		if got, ok := monitor.Metrics[key]; !ok || got != want {
+			t.Errorf("Metric %s: expected %v, got %v (present: %v)", key, want, got, ok)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -62,6 +63,16 @@ func (m *CIPerformanceMonitor) RecordArtifact(artifactType string, duration time
 	m.Metrics[fmt.Sprintf("artifact_%s_ms", artifactType)] = float64(duration.Milliseconds())
 }
 
+// RecordBenchmarks records parsed benchmark timings as bench_<name>_ms metrics,
+// so that measured capture and report costs are saved with the report
+func (m *CIPerformanceMonitor) RecordBenchmarks(results []BenchResult) {
+	for _, result := range results {
+		name := strings.TrimPrefix(result.Name, "Benchmark")
+		name = strings.NewReplacer("/", "_", "-", "_").Replace(name)
+		m.Metrics[fmt.Sprintf("bench_%s_ms", name)] = result.NsPerOp / float64(time.Millisecond)
+	}
+}
+
 // Finish completes monitoring and validates thresholds
 func (m *CIPerformanceMonitor) Finish() error {
 	m.EndTime = time.Now()
@@ -134,6 +145,39 @@ Timestamp: %s
 	return nil
 }
 
+// invalidMetric stands in for metrics that are NaN or infinite, such as a
+// rate over a zero duration, when a report is encoded. Metrics are durations,
+// counts and rates, which are never negative, so it cannot be mistaken for a
+// measurement.
+const invalidMetric = -1
+
+// MarshalJSON encodes the monitor with non-finite metrics replaced by
+// invalidMetric. JSON has no NaN or infinity, so a single such metric would
+// otherwise make json.Marshal fail and the whole report go unwritten.
+func (m CIPerformanceMonitor) MarshalJSON() ([]byte, error) {
+	type monitorJSON CIPerformanceMonitor
+	report := monitorJSON(m)
+	report.Metrics = finiteMetrics(m.Metrics)
+	return json.Marshal(report)
+}
+
+// finiteMetrics returns a copy of metrics with NaN and infinite values
+// replaced by invalidMetric
+func finiteMetrics(metrics map[string]float64) map[string]float64 {
+	if metrics == nil {
+		return nil
+	}
+
+	finite := make(map[string]float64, len(metrics))
+	for name, value := range metrics {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			value = invalidMetric
+		}
+		finite[name] = value
+	}
+	return finite
+}
+
 // getStatus returns PASS/FAIL based on threshold validation
 func (m *CIPerformanceMonitor) getStatus() string {
 	if m.TotalDuration > m.Thresholds.MaxCIOverhead {
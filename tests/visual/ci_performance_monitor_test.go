@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
@@ -204,6 +205,87 @@ func TestCIPerformanceThresholds(t *testing.T) {
 	})
 }
 
+func TestCIPerformanceNonFiniteMetrics(t *testing.T) {
+	newMonitor := func() *CIPerformanceMonitor {
+		monitor := NewCIPerformanceMonitor()
+		monitor.Start()
+		monitor.RecordScreenshot(100 * time.Millisecond)
+
+		// A throughput over a zero duration and a rate of zero over zero
+		var elapsed float64
+		monitor.Metrics["screenshots_per_second"] = float64(monitor.ScreenshotCount) / elapsed
+		monitor.Metrics["failure_rate"] = elapsed / elapsed
+		monitor.Metrics["slowdown_score"] = math.Inf(-1)
+		return monitor
+	}
+
+	checkMetrics := func(t *testing.T, metrics map[string]float64) {
+		t.Helper()
+		expected := map[string]float64{
+			"screenshot_1_ms":        100,
+			"screenshots_per_second": invalidMetric,
+			"failure_rate":           invalidMetric,
+			"slowdown_score":         invalidMetric,
+		}
+		for name, want := range expected {
+			if got, ok := metrics[name]; !ok || got != want {
+				t.Errorf("Metric %s: expected %v, got %v (present: %v)", name, want, got, ok)
+			}
+		}
+	}
+
+	t.Run("save_report", func(t *testing.T) {
+		monitor := newMonitor()
+		tempDir := t.TempDir()
+		if err := monitor.SaveReport(tempDir); err != nil {
+			t.Fatalf("SaveReport failed with non-finite metrics: %v", err)
+		}
+
+		files, err := filepath.Glob(filepath.Join(tempDir, "ci_performance_*.json"))
+		if err != nil || len(files) != 1 {
+			t.Fatalf("Expected one JSON report, got %v (%v)", files, err)
+		}
+		data, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatalf("Failed to read report: %v", err)
+		}
+
+		var saved CIPerformanceMonitor
+		if err := json.Unmarshal(data, &saved); err != nil {
+			t.Fatalf("Saved report is not valid JSON: %v", err)
+		}
+		checkMetrics(t, saved.Metrics)
+
+		// Sanitizing only affects the encoded report
+		if !math.IsInf(monitor.Metrics["screenshots_per_second"], 1) {
+			t.Errorf("Expected the monitor's own metric to stay infinite, got %v", monitor.Metrics["screenshots_per_second"])
+		}
+	})
+
+	t.Run("dashboard_json", func(t *testing.T) {
+		dashboard := NewPerformanceDashboard()
+		dashboard.Reports = append(dashboard.Reports, *newMonitor())
+
+		path := filepath.Join(t.TempDir(), "dashboard.json")
+		if err := dashboard.SaveJSON(path); err != nil {
+			t.Fatalf("SaveJSON failed with non-finite metrics: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read dashboard: %v", err)
+		}
+		var saved PerformanceDashboard
+		if err := json.Unmarshal(data, &saved); err != nil {
+			t.Fatalf("Saved dashboard is not valid JSON: %v", err)
+		}
+		if len(saved.Reports) != 1 {
+			t.Fatalf("Expected one report, got %d", len(saved.Reports))
+		}
+		checkMetrics(t, saved.Reports[0].Metrics)
+	})
+}
+
 func TestCIDetection(t *testing.T) {
 	t.Run("detect_github_actions", func(t *testing.T) {
 		// Save original value
@@ -344,16 +344,8 @@ func testWindowsPerformanceProfile(t *testing.T, outputDir string) {
 }
 
 func compareVisualBaselines(baseline1, baseline2 string) bool {
-	// Simple baseline comparison - in real implementation would do image diff
-	data1, err1 := os.ReadFile(baseline1)
-	data2, err2 := os.ReadFile(baseline2)
-
-	if err1 != nil || err2 != nil {
-		return false
-	}
-
-	// Return false if different (which is expected for our test)
-	return len(data1) == len(data2)
+	result, err := CompareImages(baseline1, baseline2)
+	return err == nil && result.Identical()
 }
 
 func measureDirectorySize(dir string) int64 {
@@ -0,0 +1,138 @@
+package visual
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+
+	"github.com/disintegration/imaging"
+)
+
+// diffHighlight marks changed pixels in a diff image
+var diffHighlight = color.NRGBA{R: 255, A: 255}
+
+// diffFade is how much of an unchanged pixel's color is kept in a diff image,
+// the rest being blended with white so that changes stand out
+const diffFade = 0.25
+
+// DiffOptions configures CompareImagesWithOptions
+type DiffOptions struct {
+	// ResizeToMatch scales the candidate to the baseline's size instead of
+	// failing when their dimensions differ
+	ResizeToMatch bool
+}
+
+// DiffResult describes how a candidate image differs from its baseline
+type DiffResult struct {
+	DiffPixels  int             // Number of pixels whose color differs
+	TotalPixels int             // Number of pixels compared
+	DiffPercent float64         // DiffPixels as a percentage of TotalPixels
+	Bounds      image.Rectangle // Smallest rectangle holding every changed pixel, empty when identical
+}
+
+// Identical reports whether no pixels differ
+func (d DiffResult) Identical() bool {
+	return d.DiffPixels == 0
+}
+
+// CompareImages compares two images pixel by pixel. Images of different sizes
+// are an error; use CompareImagesWithOptions to resize the candidate instead.
+func CompareImages(baselinePath, candidatePath string) (DiffResult, error) {
+	return CompareImagesWithOptions(baselinePath, candidatePath, DiffOptions{})
+}
+
+// CompareImagesWithOptions compares two images pixel by pixel
+func CompareImagesWithOptions(baselinePath, candidatePath string, opts DiffOptions) (DiffResult, error) {
+	baseline, candidate, err := loadImagePair(baselinePath, candidatePath, opts)
+	if err != nil {
+		return DiffResult{}, err
+	}
+
+	size := baseline.Bounds().Size()
+	result := DiffResult{TotalPixels: size.X * size.Y}
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			if !pixelDiffers(baseline, candidate, x, y) {
+				continue
+			}
+			result.DiffPixels++
+			result.Bounds = result.Bounds.Union(image.Rect(x, y, x+1, y+1))
+		}
+	}
+
+	if result.TotalPixels > 0 {
+		result.DiffPercent = float64(result.DiffPixels) / float64(result.TotalPixels) * 100
+	}
+	return result, nil
+}
+
+// GenerateDiffImage writes a PNG to outPath showing the baseline with
+// unchanged pixels faded and changed pixels in red. The images must be the
+// same size.
+func GenerateDiffImage(baselinePath, candidatePath, outPath string) error {
+	baseline, candidate, err := loadImagePair(baselinePath, candidatePath, DiffOptions{})
+	if err != nil {
+		return err
+	}
+
+	size := baseline.Bounds().Size()
+	diff := imaging.New(size.X, size.Y, color.White)
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			if pixelDiffers(baseline, candidate, x, y) {
+				diff.SetNRGBA(x, y, diffHighlight)
+			} else {
+				diff.SetNRGBA(x, y, fadePixel(baseline.NRGBAAt(x, y)))
+			}
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		return fmt.Errorf("failed to create diff output directory: %w", err)
+	}
+	if err := imaging.Save(diff, outPath); err != nil {
+		return fmt.Errorf("failed to save diff image %s: %w", outPath, err)
+	}
+	return nil
+}
+
+// fadePixel blends a pixel with white, keeping diffFade of its color
+func fadePixel(c color.NRGBA) color.NRGBA {
+	fade := func(v uint8) uint8 {
+		return uint8(255 - (255-float64(v))*diffFade)
+	}
+	return color.NRGBA{R: fade(c.R), G: fade(c.G), B: fade(c.B), A: 255}
+}
+
+// loadImagePair decodes a baseline and candidate image into NRGBA images of
+// the same size, both anchored at the origin
+func loadImagePair(baselinePath, candidatePath string, opts DiffOptions) (*image.NRGBA, *image.NRGBA, error) {
+	baselineImg, err := imaging.Open(baselinePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open baseline image %s: %w", baselinePath, err)
+	}
+	candidateImg, err := imaging.Open(candidatePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open candidate image %s: %w", candidatePath, err)
+	}
+
+	baseline := imaging.Clone(baselineImg)
+	candidate := imaging.Clone(candidateImg)
+
+	want, got := baseline.Bounds().Size(), candidate.Bounds().Size()
+	if want != got {
+		if !opts.ResizeToMatch {
+			return nil, nil, fmt.Errorf("image size mismatch: baseline is %dx%d, candidate is %dx%d", want.X, want.Y, got.X, got.Y)
+		}
+		candidate = imaging.Resize(candidate, want.X, want.Y, imaging.NearestNeighbor)
+	}
+	return baseline, candidate, nil
+}
+
+// pixelDiffers reports whether the pixel at x, y has a different color in the two images
+func pixelDiffers(a, b *image.NRGBA, x, y int) bool {
+	i, j := a.PixOffset(x, y), b.PixOffset(x, y)
+	return a.Pix[i] != b.Pix[j] || a.Pix[i+1] != b.Pix[j+1] || a.Pix[i+2] != b.Pix[j+2] || a.Pix[i+3] != b.Pix[j+3]
+}
@@ -0,0 +1,150 @@
+package visual
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/disintegration/imaging"
+)
+
+// saveTestImage writes a solid image with an optional filled region and returns its path
+func saveTestImage(t *testing.T, name string, width, height int, region image.Rectangle, fill color.Color) string {
+	t.Helper()
+	img := imaging.New(width, height, color.White)
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := imaging.Save(img, path); err != nil {
+		t.Fatalf("Failed to save test image: %v", err)
+	}
+	return path
+}
+
+// TestCompareImages tests pixel-by-pixel comparison of images
+func TestCompareImages(t *testing.T) {
+	baseline := saveTestImage(t, "baseline.png", 20, 10, image.Rectangle{}, nil)
+
+	t.Run("identical", func(t *testing.T) {
+		same := saveTestImage(t, "same.png", 20, 10, image.Rectangle{}, nil)
+		result, err := CompareImages(baseline, same)
+		if err != nil {
+			t.Fatalf("CompareImages failed: %v", err)
+		}
+		if !result.Identical() || result.DiffPercent != 0 || !result.Bounds.Empty() {
+			t.Errorf("Expected identical images, got %+v", result)
+		}
+		if result.TotalPixels != 200 {
+			t.Errorf("Expected 200 pixels compared, got %d", result.TotalPixels)
+		}
+	})
+
+	t.Run("changed_region", func(t *testing.T) {
+		region := image.Rect(2, 3, 7, 5)
+		changed := saveTestImage(t, "changed.png", 20, 10, region, color.Black)
+		result, err := CompareImages(baseline, changed)
+		if err != nil {
+			t.Fatalf("CompareImages failed: %v", err)
+		}
+		if result.DiffPixels != 10 {
+			t.Errorf("Expected 10 changed pixels, got %d", result.DiffPixels)
+		}
+		if result.DiffPercent != 5 {
+			t.Errorf("Expected 5%% changed, got %v", result.DiffPercent)
+		}
+		if result.Bounds != region {
+			t.Errorf("Expected bounds %v, got %v", region, result.Bounds)
+		}
+	})
+
+	t.Run("size_mismatch", func(t *testing.T) {
+		larger := saveTestImage(t, "larger.png", 40, 20, image.Rectangle{}, nil)
+		_, err := CompareImages(baseline, larger)
+		if err == nil || !strings.Contains(err.Error(), "image size mismatch: baseline is 20x10, candidate is 40x20") {
+			t.Errorf("Expected a size mismatch error, got %v", err)
+		}
+
+		result, err := CompareImagesWithOptions(baseline, larger, DiffOptions{ResizeToMatch: true})
+		if err != nil {
+			t.Fatalf("CompareImagesWithOptions failed: %v", err)
+		}
+		if !result.Identical() || result.TotalPixels != 200 {
+			t.Errorf("Expected the resized candidate to match, got %+v", result)
+		}
+	})
+
+	t.Run("missing_file", func(t *testing.T) {
+		_, err := CompareImages(baseline, filepath.Join(t.TempDir(), "missing.png"))
+		if err == nil || !strings.Contains(err.Error(), "failed to open candidate image") {
+			t.Errorf("Expected an open error, got %v", err)
+		}
+	})
+}
+
+// TestGenerateDiffImage tests that changed pixels are drawn red and the rest faded
+func TestGenerateDiffImage(t *testing.T) {
+	region := image.Rect(5, 2, 9, 6)
+	baseline := saveTestImage(t, "baseline.png", 12, 8, image.Rectangle{}, nil)
+	candidate := saveTestImage(t, "candidate.png", 12, 8, region, color.Black)
+	outPath := filepath.Join(t.TempDir(), "diffs", "diff.png")
+
+	if err := GenerateDiffImage(baseline, candidate, outPath); err != nil {
+		t.Fatalf("GenerateDiffImage failed: %v", err)
+	}
+	if _, err := os.Stat(outPath); err != nil {
+		t.Fatalf("Expected diff image at %s: %v", outPath, err)
+	}
+
+	diffImg, err := imaging.Open(outPath)
+	if err != nil {
+		t.Fatalf("Failed to open diff image: %v", err)
+	}
+	diff := imaging.Clone(diffImg)
+	if diff.Bounds().Size() != image.Pt(12, 8) {
+		t.Fatalf("Expected a 12x8 diff image, got %v", diff.Bounds().Size())
+	}
+
+	red := color.NRGBA{R: 255, A: 255}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 12; x++ {
+			pixel := diff.NRGBAAt(x, y)
+			if image.Pt(x, y).In(region) {
+				if pixel != red {
+					t.Fatalf("Expected changed pixel (%d, %d) to be red, got %v", x, y, pixel)
+				}
+			} else if pixel == red {
+				t.Fatalf("Expected unchanged pixel (%d, %d) not to be red", x, y)
+			}
+		}
+	}
+
+	t.Run("unchanged_pixels_faded", func(t *testing.T) {
+		dark := saveTestImage(t, "dark.png", 4, 4, image.Rect(0, 0, 4, 4), color.Black)
+		out := filepath.Join(t.TempDir(), "faded.png")
+		if err := GenerateDiffImage(dark, dark, out); err != nil {
+			t.Fatalf("GenerateDiffImage failed: %v", err)
+		}
+		fadedImg, err := imaging.Open(out)
+		if err != nil {
+			t.Fatalf("Failed to open diff image: %v", err)
+		}
+		if pixel := imaging.Clone(fadedImg).NRGBAAt(0, 0); pixel.R < 128 || pixel.R != pixel.G || pixel.G != pixel.B {
+			t.Errorf("Expected black to fade to a light gray, got %v", pixel)
+		}
+	})
+
+	t.Run("size_mismatch", func(t *testing.T) {
+		smaller := saveTestImage(t, "smaller.png", 6, 4, image.Rectangle{}, nil)
+		err := GenerateDiffImage(baseline, smaller, filepath.Join(t.TempDir(), "diff.png"))
+		if err == nil || !strings.Contains(err.Error(), "image size mismatch") {
+			t.Errorf("Expected a size mismatch error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package visual
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// RedactionMask replaces secrets in reports
+const RedactionMask = "***"
+
+// DefaultSecretPatterns match common secret formats
+var DefaultSecretPatterns = []string{
+	`(?i)\b(password|passwd|pwd|secret|token|api[_-]?key)\s*[:=]\s*\S+`, // key=value assignments
+	`\bgh[pousr]_[A-Za-z0-9]{20,}\b`,                                    // GitHub tokens
+	`\bAKIA[0-9A-Z]{16}\b`,                                              // AWS access key IDs
+	`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`,                              // Bearer tokens
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----`,                                // PEM private keys
+}
+
+// sensitiveMetadataKeys mark metadata entries whose values are always masked,
+// when they are the last word of the key
+var sensitiveMetadataKeys = []string{"password", "passwd", "secret", "token", "apikey", "credential", "credentials"}
+
+// Redactor masks secrets in text written to reports
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor creates a redactor from a list of regular expressions
+func NewRedactor(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// NewDefaultRedactor creates a redactor using DefaultSecretPatterns
+func NewDefaultRedactor() *Redactor {
+	r, err := NewRedactor(DefaultSecretPatterns)
+	if err != nil {
+		panic(err) // The default patterns are constant and known to compile
+	}
+	return r
+}
+
+// Redact replaces every match of the redactor's patterns with RedactionMask.
+// A nil redactor returns the text unchanged.
+func (r *Redactor) Redact(text string) string {
+	if r == nil {
+		return text
+	}
+	for _, re := range r.patterns {
+		text = re.ReplaceAllString(text, RedactionMask)
+	}
+	return text
+}
+
+// RedactMetadata returns a copy of metadata with sensitive keys masked and
+// secrets in string values redacted
+func (r *Redactor) RedactMetadata(metadata map[string]interface{}) map[string]interface{} {
+	if r == nil || metadata == nil {
+		return metadata
+	}
+
+	redacted := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		switch {
+		case isSensitiveMetadataKey(key):
+			redacted[key] = RedactionMask
+		case isString(value):
+			redacted[key] = r.Redact(value.(string))
+		default:
+			redacted[key] = value
+		}
+	}
+	return redacted
+}
+
+// isSensitiveMetadataKey checks if a metadata key names a secret. The key is
+// split into words and its last word decides, so auth_token and dbPassword
+// are secrets while token_count and max_tokens are not. "api key" is joined
+// into one word.
+func isSensitiveMetadataKey(key string) bool {
+	words := keyWords(key)
+	if len(words) == 0 {
+		return false
+	}
+	last := words[len(words)-1]
+	if last == "key" && len(words) > 1 && words[len(words)-2] == "api" {
+		last = "apikey"
+	}
+	for _, marker := range sensitiveMetadataKeys {
+		if last == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// keyWords splits a key into lowercase words at underscores, hyphens, dots,
+// spaces and camelCase boundaries, so "maxTokens", "max_tokens" and
+// "APIKey" become [max tokens] and [api key]
+func keyWords(key string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(key)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == '.' || unicode.IsSpace(r) {
+			flush()
+			continue
+		}
+		// A capital starts a word after a lowercase letter or digit, and
+		// ends an acronym when a lowercase letter follows it
+		if unicode.IsUpper(r) && i > 0 && len(word) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// isString checks if a metadata value is a string
+func isString(value interface{}) bool {
+	_, ok := value.(string)
+	return ok
+}
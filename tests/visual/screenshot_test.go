@@ -2,9 +2,14 @@ package visual
 
 import (
 	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -83,6 +88,66 @@ func TestCrossPlatformScreenshot(t *testing.T) {
 	t.Logf("Cross-platform screenshot test completed: %s", filepath)
 }
 
+// TestScreenshotFilenames tests the deterministic <test>_<event>_<seq>.png naming
+func TestScreenshotFilenames(t *testing.T) {
+	t.Run("filename_scheme", func(t *testing.T) {
+		tests := []struct {
+			testName  string
+			eventType string
+			sequence  int
+			expected  string
+		}{
+			{"calculator", "start", 0, "calculator_start_0.png"},
+			{"calculator", "pass", 1, "calculator_pass_1.png"},
+			{"calculator_visual", "complete", 12, "calculator_visual_complete_12.png"},
+		}
+
+		for _, tt := range tests {
+			if got := ScreenshotFilename(tt.testName, tt.eventType, tt.sequence); got != tt.expected {
+				t.Errorf("ScreenshotFilename(%q, %q, %d) = %q, want %q",
+					tt.testName, tt.eventType, tt.sequence, got, tt.expected)
+			}
+		}
+	})
+
+	t.Run("sequential_captures", func(t *testing.T) {
+		if err := setupVirtualDisplay(); err != nil {
+			t.Logf("Warning: Could not setup virtual display: %v", err)
+		}
+
+		outputDir := t.TempDir()
+		logger := NewVisualTestLogger("sequence_test", outputDir)
+		logger.LogEvent(EventTestStart, "First capture", nil)
+		logger.LogEvent(EventTestStart, "Second capture", nil)
+
+		if len(logger.Screenshots) != 2 {
+			t.Skip("Screenshot capture not available in test environment")
+			return
+		}
+
+		for i, screenshot := range logger.Screenshots {
+			expected := ScreenshotFilename("sequence_test", string(EventTestStart), i)
+			if filepath.Base(screenshot) != expected {
+				t.Errorf("Capture %d: expected %s, got %s", i, expected, filepath.Base(screenshot))
+			}
+			if _, err := os.Stat(screenshot); err != nil {
+				t.Errorf("Screenshot file missing: %v", err)
+			}
+		}
+
+		// Report links must reference the files that were written
+		html, err := logger.generateHTMLReport()
+		if err != nil {
+			t.Fatalf("generateHTMLReport failed: %v", err)
+		}
+		for _, screenshot := range logger.Screenshots {
+			if !strings.Contains(html, `src="`+filepath.Base(screenshot)+`"`) {
+				t.Errorf("Report does not link to %s", filepath.Base(screenshot))
+			}
+		}
+	})
+}
+
 // BenchmarkScreenshotCapture benchmarks screenshot performance
 func BenchmarkScreenshotCapture(b *testing.B) {
 	// Set up virtual display for CI environments
@@ -138,3 +203,45 @@ func setupVirtualDisplay() error {
 
 	return nil
 }
+
+// TestStdlibPNGEncoder tests saving images with the standard library encoder
+func TestStdlibPNGEncoder(t *testing.T) {
+	testDir := t.TempDir()
+
+	// Synthetic gradient so pixel values can be checked after decoding
+	img := image.NewRGBA(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: uint8(y * 32), B: 128, A: 255})
+		}
+	}
+
+	capture := NewScreenshotCapture("encoder_test", testDir)
+	capture.Encoder = EncoderStdlib
+
+	filePath := filepath.Join(testDir, "encoder_test.png")
+	if err := capture.saveImage(img, filePath); err != nil {
+		t.Fatalf("Failed to save with stdlib encoder: %v", err)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		t.Fatalf("Failed to open saved image: %v", err)
+	}
+	defer file.Close()
+
+	decoded, err := png.Decode(file)
+	if err != nil {
+		t.Fatalf("Saved file is not a valid PNG: %v", err)
+	}
+
+	if decoded.Bounds() != img.Bounds() {
+		t.Errorf("Expected bounds %v, got %v", img.Bounds(), decoded.Bounds())
+	}
+
+	r, g, b, a := decoded.At(3, 5).RGBA()
+	er, eg, eb, ea := img.At(3, 5).RGBA()
+	if r != er || g != eg || b != eb || a != ea {
+		t.Errorf("Pixel mismatch at (3,5): got %v,%v,%v,%v want %v,%v,%v,%v", r, g, b, a, er, eg, eb, ea)
+	}
+}
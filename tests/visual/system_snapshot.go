@@ -0,0 +1,151 @@
+package visual
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// SystemSnapshot describes the machine a report was generated on, for
+// reproducing failures
+type SystemSnapshot struct {
+	OS               string            `json:"os"`
+	Architecture     string            `json:"architecture"`
+	OSVersion        string            `json:"os_version"`
+	NumCPU           int               `json:"num_cpu"`
+	TotalMemoryBytes uint64            `json:"total_memory_bytes"` // 0 if unknown
+	GoVersion        string            `json:"go_version"`
+	Environment      map[string]string `json:"environment"`
+}
+
+// snapshotEnvPrefixes selects the environment variables relevant to test runs
+var snapshotEnvPrefixes = []string{"CI", "GITHUB_", "RUNNER_", "DISPLAY", "XDG_SESSION_TYPE", "LANG", "TERM"}
+
+// snapshotGoEnvNames lists the Go variables kept in the snapshot. Others such
+// as GOPROXY, GOFLAGS and GONOSUMDB can hold URLs with credentials.
+var snapshotGoEnvNames = []string{"GOOS", "GOARCH", "GOVERSION", "GOMAXPROCS"}
+
+// urlUserinfo matches the user and password part of a URL
+var urlUserinfo = regexp.MustCompile(`://[^/@\s]+@`)
+
+// sensitiveEnvMarkers excludes variables that may hold credentials
+var sensitiveEnvMarkers = []string{"TOKEN", "SECRET", "PASSWORD", "PASSWD", "KEY", "CREDENTIAL", "AUTH", "COOKIE"}
+
+// CaptureSystemSnapshot gathers CPU, memory, OS version and a filtered
+// environment snapshot
+func CaptureSystemSnapshot() SystemSnapshot {
+	return SystemSnapshot{
+		OS:               runtime.GOOS,
+		Architecture:     runtime.GOARCH,
+		OSVersion:        osVersion(),
+		NumCPU:           runtime.NumCPU(),
+		TotalMemoryBytes: totalMemory(),
+		GoVersion:        runtime.Version(),
+		Environment:      filterEnvironment(os.Environ()),
+	}
+}
+
+// filterEnvironment keeps relevant variables and drops sensitive ones. The
+// user and password of URLs in the kept values are masked.
+func filterEnvironment(environ []string) map[string]string {
+	filtered := make(map[string]string)
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !isRelevantEnvVar(name) || isSensitiveEnvVar(name) {
+			continue
+		}
+		filtered[name] = urlUserinfo.ReplaceAllString(value, "://"+RedactionMask+"@")
+	}
+	return filtered
+}
+
+// isRelevantEnvVar checks if a variable is one of the Go variables or matches
+// one of the snapshot prefixes
+func isRelevantEnvVar(name string) bool {
+	for _, goName := range snapshotGoEnvNames {
+		if name == goName {
+			return true
+		}
+	}
+	for _, prefix := range snapshotEnvPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSensitiveEnvVar checks if a variable name suggests it holds a credential
+func isSensitiveEnvVar(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, marker := range sensitiveEnvMarkers {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// osVersion returns the kernel or OS version, falling back to the OS name
+func osVersion() string {
+	var version string
+	switch runtime.GOOS {
+	case "linux":
+		if data, err := os.ReadFile("/proc/sys/kernel/osrelease"); err == nil {
+			version = strings.TrimSpace(string(data))
+		}
+	case "darwin":
+		if output, err := exec.Command("sw_vers", "-productVersion").Output(); err == nil {
+			version = strings.TrimSpace(string(output))
+		}
+	case "windows":
+		if output, err := exec.Command("cmd", "/c", "ver").Output(); err == nil {
+			version = strings.TrimSpace(string(output))
+		}
+	}
+
+	if version == "" {
+		return runtime.GOOS
+	}
+	return version
+}
+
+// totalMemory returns the physical memory size in bytes, or 0 if unknown
+func totalMemory() uint64 {
+	switch runtime.GOOS {
+	case "linux":
+		file, err := os.Open("/proc/meminfo")
+		if err != nil {
+			return 0
+		}
+		defer file.Close()
+
+		// The MemTotal line reads "MemTotal:       16318428 kB"
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) >= 2 && fields[0] == "MemTotal:" {
+				kb, err := strconv.ParseUint(fields[1], 10, 64)
+				if err != nil {
+					return 0
+				}
+				return kb * 1024
+			}
+		}
+	case "darwin":
+		output, err := exec.Command("sysctl", "-n", "hw.memsize").Output()
+		if err != nil {
+			return 0
+		}
+		bytes, err := strconv.ParseUint(strings.TrimSpace(string(output)), 10, 64)
+		if err != nil {
+			return 0
+		}
+		return bytes
+	}
+	return 0
+}
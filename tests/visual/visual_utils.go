@@ -3,9 +3,13 @@ package visual
 import (
 	"context"
 	"fmt"
+	"html/template"
+	"image"
+	"image/png"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -27,9 +31,22 @@ type ScreenshotCapture struct {
 	Timestamp time.Time
 	Format    string // "png" (default)
 	Quality   int    // 100 (lossless for PNG)
+	Sequence  int    // Sequence number of the next capture, used in the filename
+	Encoder   ImageEncoder
 	capturer  ScreenshotEngine
 }
 
+// ImageEncoder selects how captured images are written to disk
+type ImageEncoder string
+
+const (
+	// EncoderImaging saves through disintegration/imaging (default)
+	EncoderImaging ImageEncoder = "imaging"
+	// EncoderStdlib saves with the standard library image/png encoder,
+	// for plain saves that need none of the imaging transforms
+	EncoderStdlib ImageEncoder = "stdlib"
+)
+
 // ScreenshotEngine abstracts the underlying screenshot mechanism
 type ScreenshotEngine interface {
 	Capture() ([]byte, error)
@@ -89,13 +106,20 @@ func (sef *ScreenshotEngineFactory) CreateEngine() ScreenshotEngine {
 }
 
 // MockScreenshotEngine provides fallback for unsupported platforms
-type MockScreenshotEngine struct{}
+type MockScreenshotEngine struct {
+	// Image is returned by GetImageData when set, so captures skip the real
+	// screen entirely; otherwise capture falls back to robotgo
+	Image image.Image
+}
 
 func (m *MockScreenshotEngine) Capture() ([]byte, error) {
 	return []byte("mock-screenshot-data"), nil
 }
 
 func (m *MockScreenshotEngine) GetImageData() (interface{}, error) {
+	if m.Image != nil {
+		return m.Image, nil
+	}
 	return "mock-image-data", nil
 }
 
@@ -116,6 +140,7 @@ func NewScreenshotCapture(testName, outputDir string) *ScreenshotCapture {
 		Timestamp: time.Now(),
 		Format:    "png",
 		Quality:   100,
+		Encoder:   EncoderImaging,
 		capturer:  factory.CreateEngine(),
 	}
 }
@@ -137,33 +162,80 @@ func (sc *ScreenshotCapture) CaptureScreen(eventType string) (string, error) {
 		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Generate filename with timestamp and event type
-	filename := fmt.Sprintf("%s_%s_%s.png",
-		sc.TestName,
-		eventType,
-		sc.Timestamp.Format("20060102_150405"))
+	filePath := filepath.Join(sc.OutputDir, ScreenshotFilename(sc.TestName, eventType, sc.Sequence))
 
-	filePath := filepath.Join(sc.OutputDir, filename)
+	img, err := sc.captureImage()
+	if err != nil {
+		return "", err
+	}
+
+	// Save with PNG format for lossless compression
+	if err := sc.saveImage(img, filePath); err != nil {
+		return "", fmt.Errorf("failed to save screenshot: %w", err)
+	}
+
+	sc.Sequence++
+	return filePath, nil
+}
+
+// SetEngine sets the engine used to capture screenshots
+func (sc *ScreenshotCapture) SetEngine(engine ScreenshotEngine) {
+	sc.capturer = engine
+}
+
+// captureImage captures the screen through the configured engine. Engines that
+// do not produce an image.Image fall back to robotgo directly, maintaining
+// proven functionality.
+func (sc *ScreenshotCapture) captureImage() (image.Image, error) {
+	if sc.capturer != nil {
+		data, err := sc.capturer.GetImageData()
+		if err != nil {
+			return nil, err
+		}
+		if img, ok := data.(image.Image); ok && img != nil {
+			return img, nil
+		}
+	}
 
-	// Capture screenshot using robotgo (maintaining proven functionality)
-	// Future: This can be abstracted through the capturer interface when needed
 	bitmap := robotgo.CaptureScreen()
 	if bitmap == nil {
-		return "", fmt.Errorf("failed to capture screen")
+		return nil, fmt.Errorf("failed to capture screen")
 	}
 
 	// Convert robotgo bitmap to standard image
 	img := robotgo.ToImage(bitmap)
 	if img == nil {
-		return "", fmt.Errorf("failed to convert bitmap to image")
+		return nil, fmt.Errorf("failed to convert bitmap to image")
 	}
+	return img, nil
+}
 
-	// Save with PNG format for lossless compression
-	if err := imaging.Save(img, filePath); err != nil {
-		return "", fmt.Errorf("failed to save screenshot: %w", err)
+// saveImage writes an image using the configured encoder
+func (sc *ScreenshotCapture) saveImage(img image.Image, filePath string) error {
+	if sc.Encoder == EncoderStdlib {
+		return SavePNG(img, filePath)
 	}
+	return imaging.Save(img, filePath)
+}
 
-	return filePath, nil
+// SavePNG encodes an image as PNG using only the standard library
+func SavePNG(img image.Image, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ScreenshotFilename returns the deterministic screenshot name <test>_<event>_<seq>.png
+// shared by the capture code and the report generators
+func ScreenshotFilename(testName, eventType string, sequence int) string {
+	return fmt.Sprintf("%s_%s_%d.png", testName, eventType, sequence)
 }
 
 // CaptureTestEvent captures screenshot for specific test events
@@ -192,6 +264,18 @@ const (
 	EventTestComplete VisualTestEvent = "complete"
 )
 
+// ScreenshotErrorPolicy controls how the logger handles screenshot failures
+type ScreenshotErrorPolicy int
+
+const (
+	// RecordErrors logs the event and records the failure in its metadata (default)
+	RecordErrors ScreenshotErrorPolicy = iota
+	// IgnoreErrors logs the event without a screenshot
+	IgnoreErrors
+	// FailFast drops the event and returns the capture error
+	FailFast
+)
+
 // VisualTestLogger handles visual logging and artifact creation
 type VisualTestLogger struct {
 	TestName    string
@@ -199,7 +283,11 @@ type VisualTestLogger struct {
 	Screenshots []string
 	Events      []VisualEvent
 	StartTime   time.Time
+	ErrorPolicy ScreenshotErrorPolicy
+	Redactor    *Redactor // Masks secrets in logged events; nil disables redaction
 	observers   []VisualTestObserver
+	engine      ScreenshotEngine // Overrides the default engine when set
+	sequence    int              // Sequence number of the next screenshot
 	mu          sync.RWMutex
 }
 
@@ -239,6 +327,8 @@ type VisualEvent struct {
 	Timestamp   time.Time
 	Description string
 	Screenshot  string
+	Expression  string // Expression on screen when the event was logged, if any
+	Result      string // Result (or error) of that expression, if any
 	Metadata    map[string]interface{}
 }
 
@@ -250,6 +340,7 @@ func NewVisualTestLogger(testName, outputDir string) *VisualTestLogger {
 		Screenshots: make([]string, 0),
 		Events:      make([]VisualEvent, 0),
 		StartTime:   time.Now(),
+		Redactor:    NewDefaultRedactor(),
 		observers:   make([]VisualTestObserver, 0),
 	}
 }
@@ -303,25 +394,79 @@ func (vtl *VisualTestLogger) notifyCompletionObservers() {
 	}
 }
 
-// LogEvent logs a visual test event with optional screenshot
-func (vtl *VisualTestLogger) LogEvent(eventType VisualTestEvent, description string, metadata map[string]interface{}) {
+// SetScreenshotEngine sets the engine used to capture event screenshots
+func (vtl *VisualTestLogger) SetScreenshotEngine(engine ScreenshotEngine) {
+	vtl.engine = engine
+}
+
+// LogEvent logs a visual test event with optional screenshot. Secrets in the
+// description and metadata are masked by the logger's Redactor before the event
+// is recorded. A screenshot failure is handled according to the logger's
+// ErrorPolicy; only FailFast returns an error, in which case the event is not logged.
+func (vtl *VisualTestLogger) LogEvent(eventType VisualTestEvent, description string, metadata map[string]interface{}) error {
+	description = vtl.Redactor.Redact(description)
+	metadata = vtl.Redactor.RedactMetadata(metadata)
+
 	event := VisualEvent{
 		Type:        eventType,
 		Timestamp:   time.Now(),
 		Description: description,
 		Metadata:    metadata,
 	}
+	event.Expression, event.Result = calculationFromMetadata(metadata)
 
 	// Capture screenshot for the event
 	capture := NewScreenshotCapture(vtl.TestName, vtl.OutputDir)
-	if screenshot, err := capture.CaptureScreen(string(eventType)); err == nil {
+	if vtl.engine != nil {
+		capture.SetEngine(vtl.engine)
+	}
+	capture.Sequence = vtl.sequence
+	screenshot, err := capture.CaptureScreen(string(eventType))
+	if err == nil {
+		vtl.sequence = capture.Sequence
 		event.Screenshot = screenshot
 		vtl.Screenshots = append(vtl.Screenshots, screenshot)
 		vtl.notifyScreenshotObservers(screenshot, string(eventType))
+	} else {
+		switch vtl.ErrorPolicy {
+		case FailFast:
+			return fmt.Errorf("screenshot for %s event failed: %w", eventType, err)
+		case RecordErrors:
+			// Copy so the caller's map is not modified
+			recorded := make(map[string]interface{}, len(metadata)+1)
+			for key, value := range metadata {
+				recorded[key] = value
+			}
+			recorded["screenshot_error"] = err.Error()
+			event.Metadata = recorded
+		}
 	}
 
 	vtl.Events = append(vtl.Events, event)
 	vtl.notifyObservers(event)
+	return nil
+}
+
+// calculationFromMetadata extracts the expression and its result or error from
+// event metadata so screenshots can be captioned with the math on screen
+func calculationFromMetadata(metadata map[string]interface{}) (expression, result string) {
+	if value, ok := metadata["expression"]; ok {
+		expression = fmt.Sprintf("%v", value)
+	}
+	if value, ok := metadata["result"]; ok {
+		result = fmt.Sprintf("%v", value)
+	} else if value, ok := metadata["error"]; ok {
+		result = fmt.Sprintf("Error: %v", value)
+	}
+	return expression, result
+}
+
+// calculationCaption formats an expression and result as "expression = result"
+func calculationCaption(expression, result string) string {
+	if result == "" {
+		return expression
+	}
+	return expression + " = " + result
 }
 
 // GenerateVisualReport generates a visual test execution report
@@ -329,7 +474,10 @@ func (vtl *VisualTestLogger) GenerateVisualReport() error {
 	reportPath := filepath.Join(vtl.OutputDir, fmt.Sprintf("%s_visual_report.html", vtl.TestName))
 
 	// Create HTML report with visual timeline
-	htmlContent := vtl.generateHTMLReport()
+	htmlContent, err := vtl.generateHTMLReport()
+	if err != nil {
+		return err
+	}
 
 	if err := os.WriteFile(reportPath, []byte(htmlContent), 0644); err != nil {
 		return fmt.Errorf("failed to write visual report: %w", err)
@@ -338,12 +486,16 @@ func (vtl *VisualTestLogger) GenerateVisualReport() error {
 	return nil
 }
 
-// generateHTMLReport creates an HTML report with visual elements
-func (vtl *VisualTestLogger) generateHTMLReport() string {
-	html := `<!DOCTYPE html>
+// visualReportTemplate renders the visual test report. html/template escapes
+// test names, descriptions, captions and metadata, so markup in them is shown
+// as text rather than breaking the page.
+var visualReportTemplate = template.Must(template.New("visual_report").Funcs(template.FuncMap{
+	"base":    filepath.Base,
+	"caption": calculationCaption,
+}).Parse(`<!DOCTYPE html>
 <html>
 <head>
-    <title>Visual Test Report: ` + vtl.TestName + `</title>
+    <title>Visual Test Report: {{.TestName}}</title>
     <style>
         body { font-family: 'Monaco', 'Menlo', monospace; margin: 40px; background: #1e1e1e; color: #d4d4d4; }
         .header { border-bottom: 2px solid #32cd32; padding: 20px 0; margin-bottom: 30px; }
@@ -352,44 +504,45 @@ func (vtl *VisualTestLogger) generateHTMLReport() string {
         .timestamp { color: #808080; font-size: 12px; }
         .event-type { color: #32cd32; font-weight: bold; }
         .metadata { background: #3c3c3c; padding: 10px; margin: 10px 0; font-size: 12px; }
+        .calculation { color: #ffd700; margin: 5px 0; }
     </style>
 </head>
 <body>
     <div class="header">
         <h1>Visual Test Report</h1>
-        <h2>Test: ` + vtl.TestName + `</h2>
-        <p>Start Time: ` + vtl.StartTime.Format("2006-01-02 15:04:05") + `</p>
-        <p>Total Events: ` + fmt.Sprintf("%d", len(vtl.Events)) + `</p>
-        <p>Screenshots Captured: ` + fmt.Sprintf("%d", len(vtl.Screenshots)) + `</p>
-    </div>`
-
-	for _, event := range vtl.Events {
-		html += `
+        <h2>Test: {{.TestName}}</h2>
+        <p>Start Time: {{.StartTime.Format "2006-01-02 15:04:05"}}</p>
+        <p>Total Events: {{len .Events}}</p>
+        <p>Screenshots Captured: {{len .Screenshots}}</p>
+    </div>
+{{- range .Events}}
     <div class="event">
-        <div class="timestamp">` + event.Timestamp.Format("15:04:05.000") + `</div>
-        <div class="event-type">` + string(event.Type) + `</div>
-        <div>` + event.Description + `</div>`
-
-		if event.Screenshot != "" {
-			html += `<img src="` + filepath.Base(event.Screenshot) + `" class="screenshot" alt="Screenshot for ` + string(event.Type) + `">`
-		}
-
-		if len(event.Metadata) > 0 {
-			html += `<div class="metadata"><strong>Metadata:</strong><br>`
-			for key, value := range event.Metadata {
-				html += fmt.Sprintf("%s: %v<br>", key, value)
-			}
-			html += `</div>`
-		}
-
-		html += `</div>`
-	}
-
-	html += `
+        <div class="timestamp">{{.Timestamp.Format "15:04:05.000"}}</div>
+        <div class="event-type">{{.Type}}</div>
+        <div>{{.Description}}</div>
+        {{- if .Screenshot}}
+        <img src="{{base .Screenshot}}" class="screenshot" alt="Screenshot for {{.Type}}">
+        {{- if .Expression}}
+        <div class="calculation">{{caption .Expression .Result}}</div>
+        {{- end}}
+        {{- end}}
+        {{- if .Metadata}}
+        <div class="metadata"><strong>Metadata:</strong><br>
+        {{- range $key, $value := .Metadata}}{{$key}}: {{$value}}<br>{{end -}}
+        </div>
+        {{- end}}
+    </div>
+{{- end}}
 </body>
-</html>`
+</html>`))
 
-	return html
+// generateHTMLReport creates an HTML report with visual elements
+func (vtl *VisualTestLogger) generateHTMLReport() (string, error) {
+	var html strings.Builder
+	if err := visualReportTemplate.Execute(&html, vtl); err != nil {
+		return "", fmt.Errorf("failed to render visual report: %w", err)
+	}
+	return html.String(), nil
 }
 
 // CreateDemoStoryboard creates a visual storyboard for demo content
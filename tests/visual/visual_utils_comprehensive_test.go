@@ -80,6 +80,8 @@ func TestVisualUtilsComprehensive(t *testing.T) {
 	t.Run("VisualTestLogger_LogEvent", func(t *testing.T) {
 		outputDir := t.TempDir()
 		logger := NewVisualTestLogger("log_event_test", outputDir)
+		// Keep metadata as given even when no display is available
+		logger.ErrorPolicy = IgnoreErrors
 
 		// Test logging an event with metadata
 		metadata := map[string]interface{}{
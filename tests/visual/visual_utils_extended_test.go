@@ -2,6 +2,7 @@ package visual
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -120,7 +121,10 @@ func TestVisualUtilsExtended(t *testing.T) {
 		logger.LogEvent(EventTestPass, "Test completed successfully", map[string]interface{}{"success": true})
 
 		// Generate HTML report
-		htmlContent := logger.generateHTMLReport()
+		htmlContent, err := logger.generateHTMLReport()
+		if err != nil {
+			t.Fatalf("generateHTMLReport failed: %v", err)
+		}
 		if len(htmlContent) == 0 {
 			t.Error("Generated HTML content is empty")
 		}
@@ -338,7 +342,10 @@ func TestVisualUtilsExtended(t *testing.T) {
 			"html_test": "<script>alert('test')</script>",
 		})
 
-		htmlContent := logger.generateHTMLReport()
+		htmlContent, err := logger.generateHTMLReport()
+		if err != nil {
+			t.Fatalf("generateHTMLReport failed: %v", err)
+		}
 
 		// Ensure HTML is properly structured
 		if !strings.Contains(htmlContent, "<!DOCTYPE html>") {
@@ -438,3 +445,121 @@ func setupVirtualDisplayForTesting() error {
 
 	return nil
 }
+
+// failingScreenshotEngine is a ScreenshotEngine whose captures always fail
+type failingScreenshotEngine struct{}
+
+func (f *failingScreenshotEngine) Capture() ([]byte, error) {
+	return nil, errors.New("display unavailable")
+}
+
+func (f *failingScreenshotEngine) GetImageData() (interface{}, error) {
+	return nil, errors.New("display unavailable")
+}
+
+func (f *failingScreenshotEngine) GetPlatform() string {
+	return "failing"
+}
+
+func (f *failingScreenshotEngine) IsAvailable() bool {
+	return false
+}
+
+// TestScreenshotErrorPolicy tests how the logger handles screenshot failures
+func TestScreenshotErrorPolicy(t *testing.T) {
+	newLogger := func(policy ScreenshotErrorPolicy) *VisualTestLogger {
+		logger := NewVisualTestLogger("error_policy_test", t.TempDir())
+		logger.SetScreenshotEngine(&failingScreenshotEngine{})
+		logger.ErrorPolicy = policy
+		return logger
+	}
+
+	t.Run("default_records_errors", func(t *testing.T) {
+		logger := NewVisualTestLogger("error_policy_test", t.TempDir())
+		if logger.ErrorPolicy != RecordErrors {
+			t.Errorf("Expected default policy RecordErrors, got %v", logger.ErrorPolicy)
+		}
+	})
+
+	t.Run("record_errors", func(t *testing.T) {
+		logger := newLogger(RecordErrors)
+		metadata := map[string]interface{}{"key": "value"}
+
+		if err := logger.LogEvent(EventTestStart, "Recorded", metadata); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(logger.Events) != 1 {
+			t.Fatalf("Expected event to be logged, got %d events", len(logger.Events))
+		}
+
+		event := logger.Events[0]
+		if event.Metadata["screenshot_error"] != "display unavailable" {
+			t.Errorf("Expected screenshot error in metadata, got %v", event.Metadata["screenshot_error"])
+		}
+		if event.Metadata["key"] != "value" {
+			t.Errorf("Expected original metadata to be kept, got %v", event.Metadata)
+		}
+		if _, ok := metadata["screenshot_error"]; ok {
+			t.Error("Caller's metadata map was modified")
+		}
+	})
+
+	t.Run("ignore_errors", func(t *testing.T) {
+		logger := newLogger(IgnoreErrors)
+
+		if err := logger.LogEvent(EventTestStart, "Ignored", nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(logger.Events) != 1 {
+			t.Fatalf("Expected event to be logged, got %d events", len(logger.Events))
+		}
+		if _, ok := logger.Events[0].Metadata["screenshot_error"]; ok {
+			t.Error("Expected no screenshot error in metadata")
+		}
+		if logger.Events[0].Screenshot != "" {
+			t.Errorf("Expected no screenshot, got %s", logger.Events[0].Screenshot)
+		}
+	})
+
+	t.Run("fail_fast", func(t *testing.T) {
+		logger := newLogger(FailFast)
+
+		err := logger.LogEvent(EventTestStart, "Aborted", nil)
+		if err == nil || !strings.Contains(err.Error(), "display unavailable") {
+			t.Fatalf("Expected capture error, got %v", err)
+		}
+		if len(logger.Events) != 0 {
+			t.Errorf("Expected event to be dropped, got %d events", len(logger.Events))
+		}
+	})
+}
+
+// TestVisualReportEscaping tests that markup in logged text is escaped in the HTML report
+func TestVisualReportEscaping(t *testing.T) {
+	logger := NewVisualTestLogger(`escape<b>"test"`, t.TempDir())
+	logger.SetScreenshotEngine(&failingScreenshotEngine{})
+	logger.ErrorPolicy = IgnoreErrors
+	logger.LogEvent(EventTestProcess, "<script>alert(1)</script>", map[string]interface{}{
+		"note": `<img src=x onerror="alert(2)">`,
+	})
+
+	html, err := logger.generateHTMLReport()
+	if err != nil {
+		t.Fatalf("generateHTMLReport failed: %v", err)
+	}
+
+	for _, raw := range []string{"<script>", "<img src=x", "<b>"} {
+		if strings.Contains(html, raw) {
+			t.Errorf("Report contains unescaped markup %q", raw)
+		}
+	}
+	for _, escaped := range []string{
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+		"note: &lt;img src=x onerror=&#34;alert(2)&#34;&gt;<br>",
+		"Test: escape&lt;b&gt;&#34;test&#34;",
+	} {
+		if !strings.Contains(html, escaped) {
+			t.Errorf("Expected report to contain %q", escaped)
+		}
+	}
+}